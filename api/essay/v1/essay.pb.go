@@ -0,0 +1,4789 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: essay.proto
+
+package essayv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EvaluateRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Title            string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content          string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Grade            *int32                 `protobuf:"varint,3,opt,name=grade,proto3,oneof" json:"grade,omitempty"`
+	EssayType        *string                `protobuf:"bytes,4,opt,name=essay_type,json=essayType,proto3,oneof" json:"essay_type,omitempty"`
+	TotalScore       *int64                 `protobuf:"varint,5,opt,name=total_score,json=totalScore,proto3,oneof" json:"total_score,omitempty"`
+	Prompt           *string                `protobuf:"bytes,6,opt,name=prompt,proto3,oneof" json:"prompt,omitempty"` // 题干/写作要求
+	Standard         *string                `protobuf:"bytes,7,opt,name=standard,proto3,oneof" json:"standard,omitempty"`
+	ContentScore     *int64                 `protobuf:"varint,8,opt,name=content_score,json=contentScore,proto3,oneof" json:"content_score,omitempty"`
+	ExpressionScore  *int64                 `protobuf:"varint,9,opt,name=expression_score,json=expressionScore,proto3,oneof" json:"expression_score,omitempty"`
+	StructureScore   *int64                 `protobuf:"varint,10,opt,name=structure_score,json=structureScore,proto3,oneof" json:"structure_score,omitempty"`
+	DevelopmentScore *int64                 `protobuf:"varint,11,opt,name=development_score,json=developmentScore,proto3,oneof" json:"development_score,omitempty"`
+	Rubric           *Rubric                `protobuf:"bytes,12,opt,name=rubric,proto3,oneof" json:"rubric,omitempty"`                                           // 结构化评分量表，与rubric_id二选一
+	RubricId         *string                `protobuf:"bytes,13,opt,name=rubric_id,json=rubricId,proto3,oneof" json:"rubric_id,omitempty"`                       // 引用配置中的评分量表
+	Images           []string               `protobuf:"bytes,14,rep,name=images,proto3" json:"images,omitempty"`                                                 // 手写原稿图片（URL或base64），提供时评分接口给出卷面分
+	AppearanceScore  *int64                 `protobuf:"varint,15,opt,name=appearance_score,json=appearanceScore,proto3,oneof" json:"appearance_score,omitempty"` // 卷面满分，仅在提供images时计入
+	ReferenceEssay   *string                `protobuf:"bytes,16,opt,name=reference_essay,json=referenceEssay,proto3,oneof" json:"reference_essay,omitempty"`     // 范文，提供时与范文对比，段落以换行分隔
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_essay_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvaluateRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetGrade() int32 {
+	if x != nil && x.Grade != nil {
+		return *x.Grade
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetEssayType() string {
+	if x != nil && x.EssayType != nil {
+		return *x.EssayType
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetTotalScore() int64 {
+	if x != nil && x.TotalScore != nil {
+		return *x.TotalScore
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetPrompt() string {
+	if x != nil && x.Prompt != nil {
+		return *x.Prompt
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetStandard() string {
+	if x != nil && x.Standard != nil {
+		return *x.Standard
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetContentScore() int64 {
+	if x != nil && x.ContentScore != nil {
+		return *x.ContentScore
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetExpressionScore() int64 {
+	if x != nil && x.ExpressionScore != nil {
+		return *x.ExpressionScore
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetStructureScore() int64 {
+	if x != nil && x.StructureScore != nil {
+		return *x.StructureScore
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetDevelopmentScore() int64 {
+	if x != nil && x.DevelopmentScore != nil {
+		return *x.DevelopmentScore
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetRubric() *Rubric {
+	if x != nil {
+		return x.Rubric
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetRubricId() string {
+	if x != nil && x.RubricId != nil {
+		return *x.RubricId
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetAppearanceScore() int64 {
+	if x != nil && x.AppearanceScore != nil {
+		return *x.AppearanceScore
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetReferenceEssay() string {
+	if x != nil && x.ReferenceEssay != nil {
+		return *x.ReferenceEssay
+	}
+	return ""
+}
+
+type Rubric struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Criteria      []*RubricCriterion     `protobuf:"bytes,3,rep,name=criteria,proto3" json:"criteria,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rubric) Reset() {
+	*x = Rubric{}
+	mi := &file_essay_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rubric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rubric) ProtoMessage() {}
+
+func (x *Rubric) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rubric.ProtoReflect.Descriptor instead.
+func (*Rubric) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Rubric) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rubric) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rubric) GetCriteria() []*RubricCriterion {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+type RubricCriterion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Dimension     string                 `protobuf:"bytes,4,opt,name=dimension,proto3" json:"dimension,omitempty"` // content/expression/structure/development/appearance，为空表示不属于任何维度
+	MaxPoints     int64                  `protobuf:"varint,5,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	Levels        []*RubricLevel         `protobuf:"bytes,6,rep,name=levels,proto3" json:"levels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RubricCriterion) Reset() {
+	*x = RubricCriterion{}
+	mi := &file_essay_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RubricCriterion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RubricCriterion) ProtoMessage() {}
+
+func (x *RubricCriterion) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RubricCriterion.ProtoReflect.Descriptor instead.
+func (*RubricCriterion) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RubricCriterion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RubricCriterion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RubricCriterion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RubricCriterion) GetDimension() string {
+	if x != nil {
+		return x.Dimension
+	}
+	return ""
+}
+
+func (x *RubricCriterion) GetMaxPoints() int64 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+func (x *RubricCriterion) GetLevels() []*RubricLevel {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type RubricLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinPoints     int64                  `protobuf:"varint,2,opt,name=min_points,json=minPoints,proto3" json:"min_points,omitempty"`
+	Descriptor_   string                 `protobuf:"bytes,3,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RubricLevel) Reset() {
+	*x = RubricLevel{}
+	mi := &file_essay_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RubricLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RubricLevel) ProtoMessage() {}
+
+func (x *RubricLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RubricLevel.ProtoReflect.Descriptor instead.
+func (*RubricLevel) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RubricLevel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RubricLevel) GetMinPoints() int64 {
+	if x != nil {
+		return x.MinPoints
+	}
+	return 0
+}
+
+func (x *RubricLevel) GetDescriptor_() string {
+	if x != nil {
+		return x.Descriptor_
+	}
+	return ""
+}
+
+type StreamEvaluateResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Type         string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                     // 响应类型: "progress", "step_error", "warning", "complete", "error"
+	Step         string                 `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`                                     // 当前步骤
+	Progress     int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`                            // 进度百分比 (0-100)
+	Message      string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                               // 状态消息
+	Timestamp    int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                          // 时间戳
+	EtaSeconds   int32                  `protobuf:"varint,6,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`      // 预计剩余秒数
+	EvaluationId string                 `protobuf:"bytes,7,opt,name=evaluation_id,json=evaluationId,proto3" json:"evaluation_id,omitempty"` // 批改记录ID，仅complete消息携带
+	// Types that are valid to be assigned to Data:
+	//
+	//	*StreamEvaluateResponse_Init
+	//	*StreamEvaluateResponse_StepData
+	//	*StreamEvaluateResponse_Result
+	//	*StreamEvaluateResponse_Error
+	Data          isStreamEvaluateResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEvaluateResponse) Reset() {
+	*x = StreamEvaluateResponse{}
+	mi := &file_essay_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEvaluateResponse) ProtoMessage() {}
+
+func (x *StreamEvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEvaluateResponse.ProtoReflect.Descriptor instead.
+func (*StreamEvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamEvaluateResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StreamEvaluateResponse) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *StreamEvaluateResponse) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *StreamEvaluateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StreamEvaluateResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *StreamEvaluateResponse) GetEtaSeconds() int32 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *StreamEvaluateResponse) GetEvaluationId() string {
+	if x != nil {
+		return x.EvaluationId
+	}
+	return ""
+}
+
+func (x *StreamEvaluateResponse) GetData() isStreamEvaluateResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *StreamEvaluateResponse) GetInit() *StreamInitData {
+	if x != nil {
+		if x, ok := x.Data.(*StreamEvaluateResponse_Init); ok {
+			return x.Init
+		}
+	}
+	return nil
+}
+
+func (x *StreamEvaluateResponse) GetStepData() *AIEvaluation {
+	if x != nil {
+		if x, ok := x.Data.(*StreamEvaluateResponse_StepData); ok {
+			return x.StepData
+		}
+	}
+	return nil
+}
+
+func (x *StreamEvaluateResponse) GetResult() *EvaluateResponse {
+	if x != nil {
+		if x, ok := x.Data.(*StreamEvaluateResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *StreamEvaluateResponse) GetError() *StreamErrorData {
+	if x != nil {
+		if x, ok := x.Data.(*StreamEvaluateResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isStreamEvaluateResponse_Data interface {
+	isStreamEvaluateResponse_Data()
+}
+
+type StreamEvaluateResponse_Init struct {
+	Init *StreamInitData `protobuf:"bytes,10,opt,name=init,proto3,oneof"` // essay_info 完成数据
+}
+
+type StreamEvaluateResponse_StepData struct {
+	StepData *AIEvaluation `protobuf:"bytes,11,opt,name=step_data,json=stepData,proto3,oneof"` // 各步骤完成数据
+}
+
+type StreamEvaluateResponse_Result struct {
+	Result *EvaluateResponse `protobuf:"bytes,12,opt,name=result,proto3,oneof"` // 完成数据
+}
+
+type StreamEvaluateResponse_Error struct {
+	Error *StreamErrorData `protobuf:"bytes,13,opt,name=error,proto3,oneof"` // 错误数据
+}
+
+func (*StreamEvaluateResponse_Init) isStreamEvaluateResponse_Data() {}
+
+func (*StreamEvaluateResponse_StepData) isStreamEvaluateResponse_Data() {}
+
+func (*StreamEvaluateResponse_Result) isStreamEvaluateResponse_Data() {}
+
+func (*StreamEvaluateResponse_Error) isStreamEvaluateResponse_Data() {}
+
+type StreamInitData struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Title                 string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Text                  []*Paragraph           `protobuf:"bytes,2,rep,name=text,proto3" json:"text,omitempty"`
+	EssayInfo             *EssayInfo             `protobuf:"bytes,3,opt,name=essay_info,json=essayInfo,proto3" json:"essay_info,omitempty"`
+	RequirementCompliance *RequirementCompliance `protobuf:"bytes,4,opt,name=requirement_compliance,json=requirementCompliance,proto3,oneof" json:"requirement_compliance,omitempty"` // 题干要求符合情况
+	GenreEvaluation       *GenreEvaluation       `protobuf:"bytes,5,opt,name=genre_evaluation,json=genreEvaluation,proto3,oneof" json:"genre_evaluation,omitempty"`                   // 文体专项检查
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StreamInitData) Reset() {
+	*x = StreamInitData{}
+	mi := &file_essay_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamInitData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamInitData) ProtoMessage() {}
+
+func (x *StreamInitData) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamInitData.ProtoReflect.Descriptor instead.
+func (*StreamInitData) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamInitData) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *StreamInitData) GetText() []*Paragraph {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+func (x *StreamInitData) GetEssayInfo() *EssayInfo {
+	if x != nil {
+		return x.EssayInfo
+	}
+	return nil
+}
+
+func (x *StreamInitData) GetRequirementCompliance() *RequirementCompliance {
+	if x != nil {
+		return x.RequirementCompliance
+	}
+	return nil
+}
+
+func (x *StreamInitData) GetGenreEvaluation() *GenreEvaluation {
+	if x != nil {
+		return x.GenreEvaluation
+	}
+	return nil
+}
+
+type StreamErrorData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Step          string                 `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`          // transport/business/invalid/canceled
+	Code          string                 `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`          // 上游业务code或HTTP状态码
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"` // 实际尝试次数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamErrorData) Reset() {
+	*x = StreamErrorData{}
+	mi := &file_essay_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamErrorData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamErrorData) ProtoMessage() {}
+
+func (x *StreamErrorData) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamErrorData.ProtoReflect.Descriptor instead.
+func (*StreamErrorData) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamErrorData) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StreamErrorData) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *StreamErrorData) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *StreamErrorData) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *StreamErrorData) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+type Paragraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sentences     []string               `protobuf:"bytes,1,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Paragraph) Reset() {
+	*x = Paragraph{}
+	mi := &file_essay_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Paragraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Paragraph) ProtoMessage() {}
+
+func (x *Paragraph) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Paragraph.ProtoReflect.Descriptor instead.
+func (*Paragraph) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Paragraph) GetSentences() []string {
+	if x != nil {
+		return x.Sentences
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Text          []*Paragraph           `protobuf:"bytes,2,rep,name=text,proto3" json:"text,omitempty"`
+	EssayInfo     *EssayInfo             `protobuf:"bytes,3,opt,name=essay_info,json=essayInfo,proto3" json:"essay_info,omitempty"`
+	AiEvaluation  *AIEvaluation          `protobuf:"bytes,4,opt,name=ai_evaluation,json=aiEvaluation,proto3" json:"ai_evaluation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	mi := &file_essay_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EvaluateResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EvaluateResponse) GetText() []*Paragraph {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+func (x *EvaluateResponse) GetEssayInfo() *EssayInfo {
+	if x != nil {
+		return x.EssayInfo
+	}
+	return nil
+}
+
+func (x *EvaluateResponse) GetAiEvaluation() *AIEvaluation {
+	if x != nil {
+		return x.AiEvaluation
+	}
+	return nil
+}
+
+type EssayInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EssayType     string                 `protobuf:"bytes,1,opt,name=essay_type,json=essayType,proto3" json:"essay_type,omitempty"`
+	Grade         int32                  `protobuf:"varint,2,opt,name=grade,proto3" json:"grade,omitempty"`
+	Counting      *Counting              `protobuf:"bytes,3,opt,name=counting,proto3" json:"counting,omitempty"`
+	Score         int64                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	Approximate   []string               `protobuf:"bytes,6,rep,name=approximate,proto3" json:"approximate,omitempty"`
+	Unavailable   []string               `protobuf:"bytes,7,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EssayInfo) Reset() {
+	*x = EssayInfo{}
+	mi := &file_essay_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EssayInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EssayInfo) ProtoMessage() {}
+
+func (x *EssayInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EssayInfo.ProtoReflect.Descriptor instead.
+func (*EssayInfo) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EssayInfo) GetEssayType() string {
+	if x != nil {
+		return x.EssayType
+	}
+	return ""
+}
+
+func (x *EssayInfo) GetGrade() int32 {
+	if x != nil {
+		return x.Grade
+	}
+	return 0
+}
+
+func (x *EssayInfo) GetCounting() *Counting {
+	if x != nil {
+		return x.Counting
+	}
+	return nil
+}
+
+func (x *EssayInfo) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EssayInfo) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *EssayInfo) GetApproximate() []string {
+	if x != nil {
+		return x.Approximate
+	}
+	return nil
+}
+
+func (x *EssayInfo) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+type Counting struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AdjAdvNum         int32                  `protobuf:"varint,1,opt,name=adj_adv_num,json=adjAdvNum,proto3" json:"adj_adv_num,omitempty"`
+	CharNum           int32                  `protobuf:"varint,2,opt,name=char_num,json=charNum,proto3" json:"char_num,omitempty"`
+	DieciNum          int32                  `protobuf:"varint,3,opt,name=dieci_num,json=dieciNum,proto3" json:"dieci_num,omitempty"`
+	Fluency           int32                  `protobuf:"varint,4,opt,name=fluency,proto3" json:"fluency,omitempty"`
+	GrammarMistakeNum int32                  `protobuf:"varint,5,opt,name=grammar_mistake_num,json=grammarMistakeNum,proto3" json:"grammar_mistake_num,omitempty"`
+	HighlightSentsNum int32                  `protobuf:"varint,6,opt,name=highlight_sents_num,json=highlightSentsNum,proto3" json:"highlight_sents_num,omitempty"`
+	IdiomNum          int32                  `protobuf:"varint,7,opt,name=idiom_num,json=idiomNum,proto3" json:"idiom_num,omitempty"`
+	NounTypeNum       int32                  `protobuf:"varint,8,opt,name=noun_type_num,json=nounTypeNum,proto3" json:"noun_type_num,omitempty"`
+	ParaNum           int32                  `protobuf:"varint,9,opt,name=para_num,json=paraNum,proto3" json:"para_num,omitempty"`
+	SentNum           int32                  `protobuf:"varint,10,opt,name=sent_num,json=sentNum,proto3" json:"sent_num,omitempty"`
+	UniqueWordNum     int32                  `protobuf:"varint,11,opt,name=unique_word_num,json=uniqueWordNum,proto3" json:"unique_word_num,omitempty"`
+	VerbTypeNum       int32                  `protobuf:"varint,12,opt,name=verb_type_num,json=verbTypeNum,proto3" json:"verb_type_num,omitempty"`
+	WordNum           int32                  `protobuf:"varint,13,opt,name=word_num,json=wordNum,proto3" json:"word_num,omitempty"`
+	WrittenMistakeNum int32                  `protobuf:"varint,14,opt,name=written_mistake_num,json=writtenMistakeNum,proto3" json:"written_mistake_num,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Counting) Reset() {
+	*x = Counting{}
+	mi := &file_essay_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Counting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Counting) ProtoMessage() {}
+
+func (x *Counting) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Counting.ProtoReflect.Descriptor instead.
+func (*Counting) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Counting) GetAdjAdvNum() int32 {
+	if x != nil {
+		return x.AdjAdvNum
+	}
+	return 0
+}
+
+func (x *Counting) GetCharNum() int32 {
+	if x != nil {
+		return x.CharNum
+	}
+	return 0
+}
+
+func (x *Counting) GetDieciNum() int32 {
+	if x != nil {
+		return x.DieciNum
+	}
+	return 0
+}
+
+func (x *Counting) GetFluency() int32 {
+	if x != nil {
+		return x.Fluency
+	}
+	return 0
+}
+
+func (x *Counting) GetGrammarMistakeNum() int32 {
+	if x != nil {
+		return x.GrammarMistakeNum
+	}
+	return 0
+}
+
+func (x *Counting) GetHighlightSentsNum() int32 {
+	if x != nil {
+		return x.HighlightSentsNum
+	}
+	return 0
+}
+
+func (x *Counting) GetIdiomNum() int32 {
+	if x != nil {
+		return x.IdiomNum
+	}
+	return 0
+}
+
+func (x *Counting) GetNounTypeNum() int32 {
+	if x != nil {
+		return x.NounTypeNum
+	}
+	return 0
+}
+
+func (x *Counting) GetParaNum() int32 {
+	if x != nil {
+		return x.ParaNum
+	}
+	return 0
+}
+
+func (x *Counting) GetSentNum() int32 {
+	if x != nil {
+		return x.SentNum
+	}
+	return 0
+}
+
+func (x *Counting) GetUniqueWordNum() int32 {
+	if x != nil {
+		return x.UniqueWordNum
+	}
+	return 0
+}
+
+func (x *Counting) GetVerbTypeNum() int32 {
+	if x != nil {
+		return x.VerbTypeNum
+	}
+	return 0
+}
+
+func (x *Counting) GetWordNum() int32 {
+	if x != nil {
+		return x.WordNum
+	}
+	return 0
+}
+
+func (x *Counting) GetWrittenMistakeNum() int32 {
+	if x != nil {
+		return x.WrittenMistakeNum
+	}
+	return 0
+}
+
+type AIEvaluation struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	ModelVersion           *ModelVersion           `protobuf:"bytes,1,opt,name=model_version,json=modelVersion,proto3" json:"model_version,omitempty"`
+	OverallEvaluation      *OverallEvaluation      `protobuf:"bytes,2,opt,name=overall_evaluation,json=overallEvaluation,proto3" json:"overall_evaluation,omitempty"`                   // 总评
+	WordSentenceEvaluation *WordSentenceEvaluation `protobuf:"bytes,3,opt,name=word_sentence_evaluation,json=wordSentenceEvaluation,proto3" json:"word_sentence_evaluation,omitempty"`  // 好词好句评价
+	SuggestionEvaluation   *SuggestionEvaluation   `protobuf:"bytes,4,opt,name=suggestion_evaluation,json=suggestionEvaluation,proto3" json:"suggestion_evaluation,omitempty"`          // 建议
+	ParagraphEvaluations   []*ParagraphEvaluation  `protobuf:"bytes,5,rep,name=paragraph_evaluations,json=paragraphEvaluations,proto3" json:"paragraph_evaluations,omitempty"`          // 段落点评
+	ScoreEvaluation        *ScoreEvaluation        `protobuf:"bytes,6,opt,name=score_evaluation,json=scoreEvaluation,proto3" json:"score_evaluation,omitempty"`                         // 分数点评
+	PolishingEvaluation    []*PolishingEvaluation  `protobuf:"bytes,7,rep,name=polishing_evaluation,json=polishingEvaluation,proto3" json:"polishing_evaluation,omitempty"`             // 润色点评
+	RequirementCompliance  *RequirementCompliance  `protobuf:"bytes,8,opt,name=requirement_compliance,json=requirementCompliance,proto3,oneof" json:"requirement_compliance,omitempty"` // 题干要求符合情况，未提供题干时为空
+	GenreEvaluation        *GenreEvaluation        `protobuf:"bytes,9,opt,name=genre_evaluation,json=genreEvaluation,proto3,oneof" json:"genre_evaluation,omitempty"`                   // 文体专项检查，未匹配到文体配置时为空
+	ReferenceComparison    *ReferenceComparison    `protobuf:"bytes,10,opt,name=reference_comparison,json=referenceComparison,proto3,oneof" json:"reference_comparison,omitempty"`      // 与范文的对比，未提供范文时为空
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AIEvaluation) Reset() {
+	*x = AIEvaluation{}
+	mi := &file_essay_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AIEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AIEvaluation) ProtoMessage() {}
+
+func (x *AIEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AIEvaluation.ProtoReflect.Descriptor instead.
+func (*AIEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AIEvaluation) GetModelVersion() *ModelVersion {
+	if x != nil {
+		return x.ModelVersion
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetOverallEvaluation() *OverallEvaluation {
+	if x != nil {
+		return x.OverallEvaluation
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetWordSentenceEvaluation() *WordSentenceEvaluation {
+	if x != nil {
+		return x.WordSentenceEvaluation
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetSuggestionEvaluation() *SuggestionEvaluation {
+	if x != nil {
+		return x.SuggestionEvaluation
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetParagraphEvaluations() []*ParagraphEvaluation {
+	if x != nil {
+		return x.ParagraphEvaluations
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetScoreEvaluation() *ScoreEvaluation {
+	if x != nil {
+		return x.ScoreEvaluation
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetPolishingEvaluation() []*PolishingEvaluation {
+	if x != nil {
+		return x.PolishingEvaluation
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetRequirementCompliance() *RequirementCompliance {
+	if x != nil {
+		return x.RequirementCompliance
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetGenreEvaluation() *GenreEvaluation {
+	if x != nil {
+		return x.GenreEvaluation
+	}
+	return nil
+}
+
+func (x *AIEvaluation) GetReferenceComparison() *ReferenceComparison {
+	if x != nil {
+		return x.ReferenceComparison
+	}
+	return nil
+}
+
+type ReferenceComparison struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Similarity    float64                `protobuf:"fixed64,1,opt,name=similarity,proto3" json:"similarity,omitempty"` // 相似度 0~100
+	Structure     *StructureComparison   `protobuf:"bytes,2,opt,name=structure,proto3" json:"structure,omitempty"`
+	SharedPhrases []string               `protobuf:"bytes,3,rep,name=shared_phrases,json=sharedPhrases,proto3" json:"shared_phrases,omitempty"`
+	Covered       []*ReferencePoint      `protobuf:"bytes,4,rep,name=covered,proto3" json:"covered,omitempty"`
+	Missed        []*ReferencePoint      `protobuf:"bytes,5,rep,name=missed,proto3" json:"missed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferenceComparison) Reset() {
+	*x = ReferenceComparison{}
+	mi := &file_essay_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferenceComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceComparison) ProtoMessage() {}
+
+func (x *ReferenceComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferenceComparison.ProtoReflect.Descriptor instead.
+func (*ReferenceComparison) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReferenceComparison) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *ReferenceComparison) GetStructure() *StructureComparison {
+	if x != nil {
+		return x.Structure
+	}
+	return nil
+}
+
+func (x *ReferenceComparison) GetSharedPhrases() []string {
+	if x != nil {
+		return x.SharedPhrases
+	}
+	return nil
+}
+
+func (x *ReferenceComparison) GetCovered() []*ReferencePoint {
+	if x != nil {
+		return x.Covered
+	}
+	return nil
+}
+
+func (x *ReferenceComparison) GetMissed() []*ReferencePoint {
+	if x != nil {
+		return x.Missed
+	}
+	return nil
+}
+
+type StructureComparison struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	ParagraphCount            int32                  `protobuf:"varint,1,opt,name=paragraph_count,json=paragraphCount,proto3" json:"paragraph_count,omitempty"`
+	ReferenceParagraphCount   int32                  `protobuf:"varint,2,opt,name=reference_paragraph_count,json=referenceParagraphCount,proto3" json:"reference_paragraph_count,omitempty"`
+	CharCount                 int32                  `protobuf:"varint,3,opt,name=char_count,json=charCount,proto3" json:"char_count,omitempty"`
+	ReferenceCharCount        int32                  `protobuf:"varint,4,opt,name=reference_char_count,json=referenceCharCount,proto3" json:"reference_char_count,omitempty"`
+	ParagraphLengths          []int32                `protobuf:"varint,5,rep,packed,name=paragraph_lengths,json=paragraphLengths,proto3" json:"paragraph_lengths,omitempty"`
+	ReferenceParagraphLengths []int32                `protobuf:"varint,6,rep,packed,name=reference_paragraph_lengths,json=referenceParagraphLengths,proto3" json:"reference_paragraph_lengths,omitempty"`
+	Differences               []string               `protobuf:"bytes,7,rep,name=differences,proto3" json:"differences,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *StructureComparison) Reset() {
+	*x = StructureComparison{}
+	mi := &file_essay_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructureComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructureComparison) ProtoMessage() {}
+
+func (x *StructureComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructureComparison.ProtoReflect.Descriptor instead.
+func (*StructureComparison) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StructureComparison) GetParagraphCount() int32 {
+	if x != nil {
+		return x.ParagraphCount
+	}
+	return 0
+}
+
+func (x *StructureComparison) GetReferenceParagraphCount() int32 {
+	if x != nil {
+		return x.ReferenceParagraphCount
+	}
+	return 0
+}
+
+func (x *StructureComparison) GetCharCount() int32 {
+	if x != nil {
+		return x.CharCount
+	}
+	return 0
+}
+
+func (x *StructureComparison) GetReferenceCharCount() int32 {
+	if x != nil {
+		return x.ReferenceCharCount
+	}
+	return 0
+}
+
+func (x *StructureComparison) GetParagraphLengths() []int32 {
+	if x != nil {
+		return x.ParagraphLengths
+	}
+	return nil
+}
+
+func (x *StructureComparison) GetReferenceParagraphLengths() []int32 {
+	if x != nil {
+		return x.ReferenceParagraphLengths
+	}
+	return nil
+}
+
+func (x *StructureComparison) GetDifferences() []string {
+	if x != nil {
+		return x.Differences
+	}
+	return nil
+}
+
+type ReferencePoint struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceParagraphIndex int32                  `protobuf:"varint,1,opt,name=reference_paragraph_index,json=referenceParagraphIndex,proto3" json:"reference_paragraph_index,omitempty"`
+	Summary                 string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	KeyPhrases              []string               `protobuf:"bytes,3,rep,name=key_phrases,json=keyPhrases,proto3" json:"key_phrases,omitempty"`
+	Coverage                float64                `protobuf:"fixed64,4,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	ParagraphIndex          int32                  `protobuf:"varint,5,opt,name=paragraph_index,json=paragraphIndex,proto3" json:"paragraph_index,omitempty"` // 作文中最接近的段落，-1表示没有
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ReferencePoint) Reset() {
+	*x = ReferencePoint{}
+	mi := &file_essay_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferencePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferencePoint) ProtoMessage() {}
+
+func (x *ReferencePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferencePoint.ProtoReflect.Descriptor instead.
+func (*ReferencePoint) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReferencePoint) GetReferenceParagraphIndex() int32 {
+	if x != nil {
+		return x.ReferenceParagraphIndex
+	}
+	return 0
+}
+
+func (x *ReferencePoint) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ReferencePoint) GetKeyPhrases() []string {
+	if x != nil {
+		return x.KeyPhrases
+	}
+	return nil
+}
+
+func (x *ReferencePoint) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+func (x *ReferencePoint) GetParagraphIndex() int32 {
+	if x != nil {
+		return x.ParagraphIndex
+	}
+	return 0
+}
+
+type GenreEvaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       string                 `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"` // 使用的文体配置
+	EssayType     string                 `protobuf:"bytes,2,opt,name=essay_type,json=essayType,proto3" json:"essay_type,omitempty"`
+	Checks        []*GenreCheck          `protobuf:"bytes,3,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenreEvaluation) Reset() {
+	*x = GenreEvaluation{}
+	mi := &file_essay_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenreEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreEvaluation) ProtoMessage() {}
+
+func (x *GenreEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreEvaluation.ProtoReflect.Descriptor instead.
+func (*GenreEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GenreEvaluation) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *GenreEvaluation) GetEssayType() string {
+	if x != nil {
+		return x.EssayType
+	}
+	return ""
+}
+
+func (x *GenreEvaluation) GetChecks() []*GenreCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type GenreCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // thesis/argument/explanation_method/narrative_elements
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Passed        bool                   `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	Evidence      []*EvidenceRef         `protobuf:"bytes,5,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenreCheck) Reset() {
+	*x = GenreCheck{}
+	mi := &file_essay_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenreCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenreCheck) ProtoMessage() {}
+
+func (x *GenreCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenreCheck.ProtoReflect.Descriptor instead.
+func (*GenreCheck) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GenreCheck) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GenreCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GenreCheck) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *GenreCheck) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *GenreCheck) GetEvidence() []*EvidenceRef {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type RequirementCompliance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AllMet        bool                   `protobuf:"varint,1,opt,name=all_met,json=allMet,proto3" json:"all_met,omitempty"`
+	Requirements  []*RequirementCheck    `protobuf:"bytes,2,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequirementCompliance) Reset() {
+	*x = RequirementCompliance{}
+	mi := &file_essay_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequirementCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequirementCompliance) ProtoMessage() {}
+
+func (x *RequirementCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequirementCompliance.ProtoReflect.Descriptor instead.
+func (*RequirementCompliance) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RequirementCompliance) GetAllMet() bool {
+	if x != nil {
+		return x.AllMet
+	}
+	return false
+}
+
+func (x *RequirementCompliance) GetRequirements() []*RequirementCheck {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+type RequirementCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // word_count/genre/title/perspective
+	Requirement   string                 `protobuf:"bytes,2,opt,name=requirement,proto3" json:"requirement,omitempty"`
+	Met           bool                   `protobuf:"varint,3,opt,name=met,proto3" json:"met,omitempty"`
+	Evidence      string                 `protobuf:"bytes,4,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequirementCheck) Reset() {
+	*x = RequirementCheck{}
+	mi := &file_essay_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequirementCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequirementCheck) ProtoMessage() {}
+
+func (x *RequirementCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequirementCheck.ProtoReflect.Descriptor instead.
+func (*RequirementCheck) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequirementCheck) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RequirementCheck) GetRequirement() string {
+	if x != nil {
+		return x.Requirement
+	}
+	return ""
+}
+
+func (x *RequirementCheck) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+func (x *RequirementCheck) GetEvidence() string {
+	if x != nil {
+		return x.Evidence
+	}
+	return ""
+}
+
+type ModelVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelVersion) Reset() {
+	*x = ModelVersion{}
+	mi := &file_essay_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelVersion) ProtoMessage() {}
+
+func (x *ModelVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelVersion.ProtoReflect.Descriptor instead.
+func (*ModelVersion) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ModelVersion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type OverallEvaluation struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Description         string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	TopicRelevanceScore int32                  `protobuf:"varint,2,opt,name=topic_relevance_score,json=topicRelevanceScore,proto3" json:"topic_relevance_score,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OverallEvaluation) Reset() {
+	*x = OverallEvaluation{}
+	mi := &file_essay_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverallEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverallEvaluation) ProtoMessage() {}
+
+func (x *OverallEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverallEvaluation.ProtoReflect.Descriptor instead.
+func (*OverallEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OverallEvaluation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *OverallEvaluation) GetTopicRelevanceScore() int32 {
+	if x != nil {
+		return x.TopicRelevanceScore
+	}
+	return 0
+}
+
+type WordSentenceEvaluation struct {
+	state               protoimpl.MessageState          `protogen:"open.v1"`
+	SentenceEvaluations []*ParagraphSentenceEvaluations `protobuf:"bytes,1,rep,name=sentence_evaluations,json=sentenceEvaluations,proto3" json:"sentence_evaluations,omitempty"`
+	WordSentenceScore   int32                           `protobuf:"varint,2,opt,name=word_sentence_score,json=wordSentenceScore,proto3" json:"word_sentence_score,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WordSentenceEvaluation) Reset() {
+	*x = WordSentenceEvaluation{}
+	mi := &file_essay_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordSentenceEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordSentenceEvaluation) ProtoMessage() {}
+
+func (x *WordSentenceEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordSentenceEvaluation.ProtoReflect.Descriptor instead.
+func (*WordSentenceEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WordSentenceEvaluation) GetSentenceEvaluations() []*ParagraphSentenceEvaluations {
+	if x != nil {
+		return x.SentenceEvaluations
+	}
+	return nil
+}
+
+func (x *WordSentenceEvaluation) GetWordSentenceScore() int32 {
+	if x != nil {
+		return x.WordSentenceScore
+	}
+	return 0
+}
+
+// ParagraphSentenceEvaluations 一个段落内各句子的评价
+type ParagraphSentenceEvaluations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sentences     []*SentenceEvaluation  `protobuf:"bytes,1,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParagraphSentenceEvaluations) Reset() {
+	*x = ParagraphSentenceEvaluations{}
+	mi := &file_essay_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParagraphSentenceEvaluations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParagraphSentenceEvaluations) ProtoMessage() {}
+
+func (x *ParagraphSentenceEvaluations) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParagraphSentenceEvaluations.ProtoReflect.Descriptor instead.
+func (*ParagraphSentenceEvaluations) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ParagraphSentenceEvaluations) GetSentences() []*SentenceEvaluation {
+	if x != nil {
+		return x.Sentences
+	}
+	return nil
+}
+
+type SentenceEvaluation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IsGoodSentence  bool                   `protobuf:"varint,1,opt,name=is_good_sentence,json=isGoodSentence,proto3" json:"is_good_sentence,omitempty"`
+	Label           string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Type            map[string]string      `protobuf:"bytes,3,rep,name=type,proto3" json:"type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 好句类型
+	WordEvaluations []*WordEvaluation      `protobuf:"bytes,4,rep,name=word_evaluations,json=wordEvaluations,proto3" json:"word_evaluations,omitempty"`                              // 好词/还需努力的词
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SentenceEvaluation) Reset() {
+	*x = SentenceEvaluation{}
+	mi := &file_essay_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SentenceEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SentenceEvaluation) ProtoMessage() {}
+
+func (x *SentenceEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SentenceEvaluation.ProtoReflect.Descriptor instead.
+func (*SentenceEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SentenceEvaluation) GetIsGoodSentence() bool {
+	if x != nil {
+		return x.IsGoodSentence
+	}
+	return false
+}
+
+func (x *SentenceEvaluation) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SentenceEvaluation) GetType() map[string]string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *SentenceEvaluation) GetWordEvaluations() []*WordEvaluation {
+	if x != nil {
+		return x.WordEvaluations
+	}
+	return nil
+}
+
+type WordEvaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Span          []int32                `protobuf:"varint,1,rep,packed,name=span,proto3" json:"span,omitempty"`
+	Type          map[string]string      `protobuf:"bytes,2,rep,name=type,proto3" json:"type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Ori           string                 `protobuf:"bytes,3,opt,name=ori,proto3" json:"ori,omitempty"`
+	Revised       string                 `protobuf:"bytes,4,opt,name=revised,proto3" json:"revised,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WordEvaluation) Reset() {
+	*x = WordEvaluation{}
+	mi := &file_essay_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordEvaluation) ProtoMessage() {}
+
+func (x *WordEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordEvaluation.ProtoReflect.Descriptor instead.
+func (*WordEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WordEvaluation) GetSpan() []int32 {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+func (x *WordEvaluation) GetType() map[string]string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *WordEvaluation) GetOri() string {
+	if x != nil {
+		return x.Ori
+	}
+	return ""
+}
+
+func (x *WordEvaluation) GetRevised() string {
+	if x != nil {
+		return x.Revised
+	}
+	return ""
+}
+
+type SuggestionEvaluation struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SuggestionDescription string                 `protobuf:"bytes,1,opt,name=suggestion_description,json=suggestionDescription,proto3" json:"suggestion_description,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SuggestionEvaluation) Reset() {
+	*x = SuggestionEvaluation{}
+	mi := &file_essay_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestionEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestionEvaluation) ProtoMessage() {}
+
+func (x *SuggestionEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestionEvaluation.ProtoReflect.Descriptor instead.
+func (*SuggestionEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SuggestionEvaluation) GetSuggestionDescription() string {
+	if x != nil {
+		return x.SuggestionDescription
+	}
+	return ""
+}
+
+type ParagraphEvaluation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ParagraphIndex int32                  `protobuf:"varint,1,opt,name=paragraph_index,json=paragraphIndex,proto3" json:"paragraph_index,omitempty"`
+	Comment        string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ParagraphEvaluation) Reset() {
+	*x = ParagraphEvaluation{}
+	mi := &file_essay_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParagraphEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParagraphEvaluation) ProtoMessage() {}
+
+func (x *ParagraphEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParagraphEvaluation.ProtoReflect.Descriptor instead.
+func (*ParagraphEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ParagraphEvaluation) GetParagraphIndex() int32 {
+	if x != nil {
+		return x.ParagraphIndex
+	}
+	return 0
+}
+
+func (x *ParagraphEvaluation) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ScoreEvaluation struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Comment        string                   `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	Comments       *Comments                `protobuf:"bytes,2,opt,name=comments,proto3" json:"comments,omitempty"`
+	Scores         *Scores                  `protobuf:"bytes,3,opt,name=scores,proto3" json:"scores,omitempty"`
+	ExamBand       *ExamBandEvaluation      `protobuf:"bytes,4,opt,name=exam_band,json=examBand,proto3,oneof" json:"exam_band,omitempty"` // 考试评分标准分类，未配置标准时为空
+	Criteria       []*CriterionScore        `protobuf:"bytes,5,rep,name=criteria,proto3" json:"criteria,omitempty"`                       // 评分量表各评分项得分
+	Adjustments    []*ScoreAdjustment       `protobuf:"bytes,6,rep,name=adjustments,proto3" json:"adjustments,omitempty"`                 // 对上游分数所做的校正
+	OffTopic       bool                     `protobuf:"varint,7,opt,name=off_topic,json=offTopic,proto3" json:"off_topic,omitempty"`      // 切题度低于阈值，已按偏题/离题限分
+	OffTopicReason string                   `protobuf:"bytes,8,opt,name=off_topic_reason,json=offTopicReason,proto3" json:"off_topic_reason,omitempty"`
+	Confidence     *ScoreConfidence         `protobuf:"bytes,9,opt,name=confidence,proto3,oneof" json:"confidence,omitempty"`                                                                  // 集成评分各次结果的一致性
+	Evidence       map[string]*EvidenceList `protobuf:"bytes,10,rep,name=evidence,proto3" json:"evidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 各维度评语的原文依据，只在complete结果中给出
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScoreEvaluation) Reset() {
+	*x = ScoreEvaluation{}
+	mi := &file_essay_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreEvaluation) ProtoMessage() {}
+
+func (x *ScoreEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreEvaluation.ProtoReflect.Descriptor instead.
+func (*ScoreEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ScoreEvaluation) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ScoreEvaluation) GetComments() *Comments {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ScoreEvaluation) GetScores() *Scores {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *ScoreEvaluation) GetExamBand() *ExamBandEvaluation {
+	if x != nil {
+		return x.ExamBand
+	}
+	return nil
+}
+
+func (x *ScoreEvaluation) GetCriteria() []*CriterionScore {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *ScoreEvaluation) GetAdjustments() []*ScoreAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *ScoreEvaluation) GetOffTopic() bool {
+	if x != nil {
+		return x.OffTopic
+	}
+	return false
+}
+
+func (x *ScoreEvaluation) GetOffTopicReason() string {
+	if x != nil {
+		return x.OffTopicReason
+	}
+	return ""
+}
+
+func (x *ScoreEvaluation) GetConfidence() *ScoreConfidence {
+	if x != nil {
+		return x.Confidence
+	}
+	return nil
+}
+
+func (x *ScoreEvaluation) GetEvidence() map[string]*EvidenceList {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type EvidenceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refs          []*EvidenceRef         `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvidenceList) Reset() {
+	*x = EvidenceList{}
+	mi := &file_essay_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceList) ProtoMessage() {}
+
+func (x *EvidenceList) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceList.ProtoReflect.Descriptor instead.
+func (*EvidenceList) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EvidenceList) GetRefs() []*EvidenceRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type EvidenceRef struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Source         string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`     // quote/good_sentence/good_word/mistake/paragraph_comment/sentence
+	Polarity       string                 `protobuf:"bytes,2,opt,name=polarity,proto3" json:"polarity,omitempty"` // positive/negative
+	ParagraphIndex int32                  `protobuf:"varint,3,opt,name=paragraph_index,json=paragraphIndex,proto3" json:"paragraph_index,omitempty"`
+	SentenceIndex  int32                  `protobuf:"varint,4,opt,name=sentence_index,json=sentenceIndex,proto3" json:"sentence_index,omitempty"` // -1表示整段
+	Span           []int32                `protobuf:"varint,5,rep,packed,name=span,proto3" json:"span,omitempty"`                                 // 句内字符区间 [start, end)
+	Text           string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Note           string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EvidenceRef) Reset() {
+	*x = EvidenceRef{}
+	mi := &file_essay_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceRef) ProtoMessage() {}
+
+func (x *EvidenceRef) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceRef.ProtoReflect.Descriptor instead.
+func (*EvidenceRef) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EvidenceRef) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *EvidenceRef) GetPolarity() string {
+	if x != nil {
+		return x.Polarity
+	}
+	return ""
+}
+
+func (x *EvidenceRef) GetParagraphIndex() int32 {
+	if x != nil {
+		return x.ParagraphIndex
+	}
+	return 0
+}
+
+func (x *EvidenceRef) GetSentenceIndex() int32 {
+	if x != nil {
+		return x.SentenceIndex
+	}
+	return 0
+}
+
+func (x *EvidenceRef) GetSpan() []int32 {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+func (x *EvidenceRef) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *EvidenceRef) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ScoreConfidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          int32                  `protobuf:"varint,1,opt,name=runs,proto3" json:"runs,omitempty"` // 成功的评分次数
+	Failed        int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Aggregate     string                 `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"` // median / trimmed_mean
+	Spreads       []*ScoreSpread         `protobuf:"bytes,4,rep,name=spreads,proto3" json:"spreads,omitempty"`
+	LowConfidence bool                   `protobuf:"varint,5,opt,name=low_confidence,json=lowConfidence,proto3" json:"low_confidence,omitempty"` // 各次评分分歧过大，建议教师复核
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreConfidence) Reset() {
+	*x = ScoreConfidence{}
+	mi := &file_essay_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreConfidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreConfidence) ProtoMessage() {}
+
+func (x *ScoreConfidence) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreConfidence.ProtoReflect.Descriptor instead.
+func (*ScoreConfidence) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ScoreConfidence) GetRuns() int32 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *ScoreConfidence) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ScoreConfidence) GetAggregate() string {
+	if x != nil {
+		return x.Aggregate
+	}
+	return ""
+}
+
+func (x *ScoreConfidence) GetSpreads() []*ScoreSpread {
+	if x != nil {
+		return x.Spreads
+	}
+	return nil
+}
+
+func (x *ScoreConfidence) GetLowConfidence() bool {
+	if x != nil {
+		return x.LowConfidence
+	}
+	return false
+}
+
+func (x *ScoreConfidence) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ScoreSpread struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Values        []int64                `protobuf:"varint,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	Aggregated    int64                  `protobuf:"varint,3,opt,name=aggregated,proto3" json:"aggregated,omitempty"`
+	Spread        int64                  `protobuf:"varint,4,opt,name=spread,proto3" json:"spread,omitempty"` // 极差
+	StdDev        float64                `protobuf:"fixed64,5,opt,name=std_dev,json=stdDev,proto3" json:"std_dev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreSpread) Reset() {
+	*x = ScoreSpread{}
+	mi := &file_essay_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreSpread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreSpread) ProtoMessage() {}
+
+func (x *ScoreSpread) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreSpread.ProtoReflect.Descriptor instead.
+func (*ScoreSpread) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ScoreSpread) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ScoreSpread) GetValues() []int64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ScoreSpread) GetAggregated() int64 {
+	if x != nil {
+		return x.Aggregated
+	}
+	return 0
+}
+
+func (x *ScoreSpread) GetSpread() int64 {
+	if x != nil {
+		return x.Spread
+	}
+	return 0
+}
+
+func (x *ScoreSpread) GetStdDev() float64 {
+	if x != nil {
+		return x.StdDev
+	}
+	return 0
+}
+
+type ScoreAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // all/content/expression/structure/development
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`   // rescale/clamp/reconcile/mismatch/off_topic
+	Original      int64                  `protobuf:"varint,3,opt,name=original,proto3" json:"original,omitempty"`
+	Adjusted      int64                  `protobuf:"varint,4,opt,name=adjusted,proto3" json:"adjusted,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreAdjustment) Reset() {
+	*x = ScoreAdjustment{}
+	mi := &file_essay_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreAdjustment) ProtoMessage() {}
+
+func (x *ScoreAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreAdjustment.ProtoReflect.Descriptor instead.
+func (*ScoreAdjustment) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ScoreAdjustment) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ScoreAdjustment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ScoreAdjustment) GetOriginal() int64 {
+	if x != nil {
+		return x.Original
+	}
+	return 0
+}
+
+func (x *ScoreAdjustment) GetAdjusted() int64 {
+	if x != nil {
+		return x.Adjusted
+	}
+	return 0
+}
+
+func (x *ScoreAdjustment) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CriterionScore struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CriterionId     string                 `protobuf:"bytes,1,opt,name=criterion_id,json=criterionId,proto3" json:"criterion_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Dimension       string                 `protobuf:"bytes,3,opt,name=dimension,proto3" json:"dimension,omitempty"`
+	Score           int64                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	MaxPoints       int64                  `protobuf:"varint,5,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	Level           string                 `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`
+	LevelDescriptor string                 `protobuf:"bytes,7,opt,name=level_descriptor,json=levelDescriptor,proto3" json:"level_descriptor,omitempty"`
+	Comment         string                 `protobuf:"bytes,8,opt,name=comment,proto3" json:"comment,omitempty"`
+	Estimated       bool                   `protobuf:"varint,9,opt,name=estimated,proto3" json:"estimated,omitempty"` // 上游未给出该项得分，按维度分数和分值比例折算
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CriterionScore) Reset() {
+	*x = CriterionScore{}
+	mi := &file_essay_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CriterionScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CriterionScore) ProtoMessage() {}
+
+func (x *CriterionScore) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CriterionScore.ProtoReflect.Descriptor instead.
+func (*CriterionScore) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CriterionScore) GetCriterionId() string {
+	if x != nil {
+		return x.CriterionId
+	}
+	return ""
+}
+
+func (x *CriterionScore) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CriterionScore) GetDimension() string {
+	if x != nil {
+		return x.Dimension
+	}
+	return ""
+}
+
+func (x *CriterionScore) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *CriterionScore) GetMaxPoints() int64 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+func (x *CriterionScore) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *CriterionScore) GetLevelDescriptor() string {
+	if x != nil {
+		return x.LevelDescriptor
+	}
+	return ""
+}
+
+func (x *CriterionScore) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *CriterionScore) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
+type ExamBandEvaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Standard      string                 `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	StandardName  string                 `protobuf:"bytes,2,opt,name=standard_name,json=standardName,proto3" json:"standard_name,omitempty"`
+	Band          *BandResult            `protobuf:"bytes,3,opt,name=band,proto3,oneof" json:"band,omitempty"`       // 总分类别，如 一类文
+	Dimensions    []*DimensionBandResult `protobuf:"bytes,4,rep,name=dimensions,proto3" json:"dimensions,omitempty"` // 各维度等级
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExamBandEvaluation) Reset() {
+	*x = ExamBandEvaluation{}
+	mi := &file_essay_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExamBandEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExamBandEvaluation) ProtoMessage() {}
+
+func (x *ExamBandEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExamBandEvaluation.ProtoReflect.Descriptor instead.
+func (*ExamBandEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExamBandEvaluation) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *ExamBandEvaluation) GetStandardName() string {
+	if x != nil {
+		return x.StandardName
+	}
+	return ""
+}
+
+func (x *ExamBandEvaluation) GetBand() *BandResult {
+	if x != nil {
+		return x.Band
+	}
+	return nil
+}
+
+func (x *ExamBandEvaluation) GetDimensions() []*DimensionBandResult {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+type BandResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Descriptor_   string                 `protobuf:"bytes,2,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+	Score         int64                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Ratio         float64                `protobuf:"fixed64,5,opt,name=ratio,proto3" json:"ratio,omitempty"` // 得分率 0~1
+	Reasons       []string               `protobuf:"bytes,6,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BandResult) Reset() {
+	*x = BandResult{}
+	mi := &file_essay_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BandResult) ProtoMessage() {}
+
+func (x *BandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BandResult.ProtoReflect.Descriptor instead.
+func (*BandResult) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BandResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BandResult) GetDescriptor_() string {
+	if x != nil {
+		return x.Descriptor_
+	}
+	return ""
+}
+
+func (x *BandResult) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *BandResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BandResult) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *BandResult) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type DimensionBandResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dimension     string                 `protobuf:"bytes,1,opt,name=dimension,proto3" json:"dimension,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Band          *BandResult            `protobuf:"bytes,3,opt,name=band,proto3" json:"band,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DimensionBandResult) Reset() {
+	*x = DimensionBandResult{}
+	mi := &file_essay_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DimensionBandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DimensionBandResult) ProtoMessage() {}
+
+func (x *DimensionBandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DimensionBandResult.ProtoReflect.Descriptor instead.
+func (*DimensionBandResult) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DimensionBandResult) GetDimension() string {
+	if x != nil {
+		return x.Dimension
+	}
+	return ""
+}
+
+func (x *DimensionBandResult) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *DimensionBandResult) GetBand() *BandResult {
+	if x != nil {
+		return x.Band
+	}
+	return nil
+}
+
+type Comments struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appearance    string                 `protobuf:"bytes,1,opt,name=appearance,proto3" json:"appearance,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Expression    string                 `protobuf:"bytes,3,opt,name=expression,proto3" json:"expression,omitempty"`
+	Structure     string                 `protobuf:"bytes,4,opt,name=structure,proto3" json:"structure,omitempty"`     // 结构-初中
+	Development   string                 `protobuf:"bytes,5,opt,name=development,proto3" json:"development,omitempty"` // 发展-高中
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comments) Reset() {
+	*x = Comments{}
+	mi := &file_essay_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comments) ProtoMessage() {}
+
+func (x *Comments) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comments.ProtoReflect.Descriptor instead.
+func (*Comments) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Comments) GetAppearance() string {
+	if x != nil {
+		return x.Appearance
+	}
+	return ""
+}
+
+func (x *Comments) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comments) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *Comments) GetStructure() string {
+	if x != nil {
+		return x.Structure
+	}
+	return ""
+}
+
+func (x *Comments) GetDevelopment() string {
+	if x != nil {
+		return x.Development
+	}
+	return ""
+}
+
+type Scores struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	All         int64                  `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	Appearance  int64                  `protobuf:"varint,2,opt,name=appearance,proto3" json:"appearance,omitempty"`
+	Content     int64                  `protobuf:"varint,3,opt,name=content,proto3" json:"content,omitempty"`
+	Expression  int64                  `protobuf:"varint,4,opt,name=expression,proto3" json:"expression,omitempty"`
+	Structure   int64                  `protobuf:"varint,5,opt,name=structure,proto3" json:"structure,omitempty"`
+	Development int64                  `protobuf:"varint,6,opt,name=development,proto3" json:"development,omitempty"`
+	// 分项分数 / 总分
+	AllWithTotal         string `protobuf:"bytes,7,opt,name=all_with_total,json=allWithTotal,proto3" json:"all_with_total,omitempty"`
+	ContentWithTotal     string `protobuf:"bytes,8,opt,name=content_with_total,json=contentWithTotal,proto3" json:"content_with_total,omitempty"`
+	ExpressionWithTotal  string `protobuf:"bytes,9,opt,name=expression_with_total,json=expressionWithTotal,proto3" json:"expression_with_total,omitempty"`
+	StructureWithTotal   string `protobuf:"bytes,10,opt,name=structure_with_total,json=structureWithTotal,proto3" json:"structure_with_total,omitempty"`
+	DevelopmentWithTotal string `protobuf:"bytes,11,opt,name=development_with_total,json=developmentWithTotal,proto3" json:"development_with_total,omitempty"`
+	AppearanceWithTotal  string `protobuf:"bytes,12,opt,name=appearance_with_total,json=appearanceWithTotal,proto3" json:"appearance_with_total,omitempty"` // 仅在提供原稿图片时给出
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Scores) Reset() {
+	*x = Scores{}
+	mi := &file_essay_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scores) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scores) ProtoMessage() {}
+
+func (x *Scores) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scores.ProtoReflect.Descriptor instead.
+func (*Scores) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Scores) GetAll() int64 {
+	if x != nil {
+		return x.All
+	}
+	return 0
+}
+
+func (x *Scores) GetAppearance() int64 {
+	if x != nil {
+		return x.Appearance
+	}
+	return 0
+}
+
+func (x *Scores) GetContent() int64 {
+	if x != nil {
+		return x.Content
+	}
+	return 0
+}
+
+func (x *Scores) GetExpression() int64 {
+	if x != nil {
+		return x.Expression
+	}
+	return 0
+}
+
+func (x *Scores) GetStructure() int64 {
+	if x != nil {
+		return x.Structure
+	}
+	return 0
+}
+
+func (x *Scores) GetDevelopment() int64 {
+	if x != nil {
+		return x.Development
+	}
+	return 0
+}
+
+func (x *Scores) GetAllWithTotal() string {
+	if x != nil {
+		return x.AllWithTotal
+	}
+	return ""
+}
+
+func (x *Scores) GetContentWithTotal() string {
+	if x != nil {
+		return x.ContentWithTotal
+	}
+	return ""
+}
+
+func (x *Scores) GetExpressionWithTotal() string {
+	if x != nil {
+		return x.ExpressionWithTotal
+	}
+	return ""
+}
+
+func (x *Scores) GetStructureWithTotal() string {
+	if x != nil {
+		return x.StructureWithTotal
+	}
+	return ""
+}
+
+func (x *Scores) GetDevelopmentWithTotal() string {
+	if x != nil {
+		return x.DevelopmentWithTotal
+	}
+	return ""
+}
+
+func (x *Scores) GetAppearanceWithTotal() string {
+	if x != nil {
+		return x.AppearanceWithTotal
+	}
+	return ""
+}
+
+type PolishingEvaluation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ParagraphIndex int32                  `protobuf:"varint,1,opt,name=paragraph_index,json=paragraphIndex,proto3" json:"paragraph_index,omitempty"`
+	Edits          []*PolishingEdit       `protobuf:"bytes,2,rep,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PolishingEvaluation) Reset() {
+	*x = PolishingEvaluation{}
+	mi := &file_essay_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolishingEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolishingEvaluation) ProtoMessage() {}
+
+func (x *PolishingEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolishingEvaluation.ProtoReflect.Descriptor instead.
+func (*PolishingEvaluation) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PolishingEvaluation) GetParagraphIndex() int32 {
+	if x != nil {
+		return x.ParagraphIndex
+	}
+	return 0
+}
+
+func (x *PolishingEvaluation) GetEdits() []*PolishingEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+type PolishingEdit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Original      string                 `protobuf:"bytes,3,opt,name=original,proto3" json:"original,omitempty"`
+	Revised       string                 `protobuf:"bytes,4,opt,name=revised,proto3" json:"revised,omitempty"`
+	SentenceIndex int32                  `protobuf:"varint,5,opt,name=sentence_index,json=sentenceIndex,proto3" json:"sentence_index,omitempty"`
+	Span          []int32                `protobuf:"varint,6,rep,packed,name=span,proto3" json:"span,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolishingEdit) Reset() {
+	*x = PolishingEdit{}
+	mi := &file_essay_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolishingEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolishingEdit) ProtoMessage() {}
+
+func (x *PolishingEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolishingEdit.ProtoReflect.Descriptor instead.
+func (*PolishingEdit) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PolishingEdit) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *PolishingEdit) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PolishingEdit) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *PolishingEdit) GetRevised() string {
+	if x != nil {
+		return x.Revised
+	}
+	return ""
+}
+
+func (x *PolishingEdit) GetSentenceIndex() int32 {
+	if x != nil {
+		return x.SentenceIndex
+	}
+	return 0
+}
+
+func (x *PolishingEdit) GetSpan() []int32 {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+type TitleOcrRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`              // OCR提供者: bee or ark
+	ImgType       string                 `protobuf:"bytes,2,opt,name=img_type,json=imgType,proto3" json:"img_type,omitempty"` // OCR识别类型: url or base64
+	Images        []string               `protobuf:"bytes,3,rep,name=images,proto3" json:"images,omitempty"`
+	LeftType      *string                `protobuf:"bytes,4,opt,name=left_type,json=leftType,proto3,oneof" json:"left_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TitleOcrRequest) Reset() {
+	*x = TitleOcrRequest{}
+	mi := &file_essay_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TitleOcrRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TitleOcrRequest) ProtoMessage() {}
+
+func (x *TitleOcrRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TitleOcrRequest.ProtoReflect.Descriptor instead.
+func (*TitleOcrRequest) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TitleOcrRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *TitleOcrRequest) GetImgType() string {
+	if x != nil {
+		return x.ImgType
+	}
+	return ""
+}
+
+func (x *TitleOcrRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *TitleOcrRequest) GetLeftType() string {
+	if x != nil && x.LeftType != nil {
+		return *x.LeftType
+	}
+	return ""
+}
+
+type TitleOcrResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TitleOcrResponse) Reset() {
+	*x = TitleOcrResponse{}
+	mi := &file_essay_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TitleOcrResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TitleOcrResponse) ProtoMessage() {}
+
+func (x *TitleOcrResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TitleOcrResponse.ProtoReflect.Descriptor instead.
+func (*TitleOcrResponse) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TitleOcrResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TitleOcrResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type StatisticsRequest struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	WordSentenceEvaluation *WordSentenceEvaluation `protobuf:"bytes,1,opt,name=word_sentence_evaluation,json=wordSentenceEvaluation,proto3" json:"word_sentence_evaluation,omitempty"` // 好词好句评价
+	ScoreEvaluation        *ScoreEvaluation        `protobuf:"bytes,2,opt,name=score_evaluation,json=scoreEvaluation,proto3" json:"score_evaluation,omitempty"`                        // 分数点评
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *StatisticsRequest) Reset() {
+	*x = StatisticsRequest{}
+	mi := &file_essay_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatisticsRequest) ProtoMessage() {}
+
+func (x *StatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatisticsRequest.ProtoReflect.Descriptor instead.
+func (*StatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *StatisticsRequest) GetWordSentenceEvaluation() *WordSentenceEvaluation {
+	if x != nil {
+		return x.WordSentenceEvaluation
+	}
+	return nil
+}
+
+func (x *StatisticsRequest) GetScoreEvaluation() *ScoreEvaluation {
+	if x != nil {
+		return x.ScoreEvaluation
+	}
+	return nil
+}
+
+type ClassStatisticsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SubmittedStudents []*StatisticsRequest   `protobuf:"bytes,1,rep,name=submitted_students,json=submittedStudents,proto3" json:"submitted_students,omitempty"`
+	TotalStudents     int32                  `protobuf:"varint,2,opt,name=total_students,json=totalStudents,proto3" json:"total_students,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ClassStatisticsRequest) Reset() {
+	*x = ClassStatisticsRequest{}
+	mi := &file_essay_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassStatisticsRequest) ProtoMessage() {}
+
+func (x *ClassStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*ClassStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ClassStatisticsRequest) GetSubmittedStudents() []*StatisticsRequest {
+	if x != nil {
+		return x.SubmittedStudents
+	}
+	return nil
+}
+
+func (x *ClassStatisticsRequest) GetTotalStudents() int32 {
+	if x != nil {
+		return x.TotalStudents
+	}
+	return 0
+}
+
+type ClassStatisticsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	SubmissionPercentage float64                `protobuf:"fixed64,1,opt,name=submission_percentage,json=submissionPercentage,proto3" json:"submission_percentage,omitempty"` // 提交率
+	OverallPerformance   *OverallPerformance    `protobuf:"bytes,2,opt,name=overall_performance,json=overallPerformance,proto3" json:"overall_performance,omitempty"`         // 整体表现
+	ErrorAnalysis        *ErrorAnalysis         `protobuf:"bytes,3,opt,name=error_analysis,json=errorAnalysis,proto3" json:"error_analysis,omitempty"`                        // 错误分析
+	HighlightAnalysis    *HighlightAnalysis     `protobuf:"bytes,4,opt,name=highlight_analysis,json=highlightAnalysis,proto3" json:"highlight_analysis,omitempty"`            // 亮点分析
+	GeneratedTime        int64                  `protobuf:"varint,5,opt,name=generated_time,json=generatedTime,proto3" json:"generated_time,omitempty"`                       // 生成时间戳
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ClassStatisticsResponse) Reset() {
+	*x = ClassStatisticsResponse{}
+	mi := &file_essay_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassStatisticsResponse) ProtoMessage() {}
+
+func (x *ClassStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*ClassStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ClassStatisticsResponse) GetSubmissionPercentage() float64 {
+	if x != nil {
+		return x.SubmissionPercentage
+	}
+	return 0
+}
+
+func (x *ClassStatisticsResponse) GetOverallPerformance() *OverallPerformance {
+	if x != nil {
+		return x.OverallPerformance
+	}
+	return nil
+}
+
+func (x *ClassStatisticsResponse) GetErrorAnalysis() *ErrorAnalysis {
+	if x != nil {
+		return x.ErrorAnalysis
+	}
+	return nil
+}
+
+func (x *ClassStatisticsResponse) GetHighlightAnalysis() *HighlightAnalysis {
+	if x != nil {
+		return x.HighlightAnalysis
+	}
+	return nil
+}
+
+func (x *ClassStatisticsResponse) GetGeneratedTime() int64 {
+	if x != nil {
+		return x.GeneratedTime
+	}
+	return 0
+}
+
+type OverallPerformance struct {
+	state                protoimpl.MessageState   `protogen:"open.v1"`
+	AverageScore         float64                  `protobuf:"fixed64,1,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
+	GradeDistribution    []*GradeDistributionItem `protobuf:"bytes,2,rep,name=grade_distribution,json=gradeDistribution,proto3" json:"grade_distribution,omitempty"`
+	SkillMasteryAnalysis []*SkillMasteryItem      `protobuf:"bytes,3,rep,name=skill_mastery_analysis,json=skillMasteryAnalysis,proto3" json:"skill_mastery_analysis,omitempty"`
+	Summary              string                   `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *OverallPerformance) Reset() {
+	*x = OverallPerformance{}
+	mi := &file_essay_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverallPerformance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverallPerformance) ProtoMessage() {}
+
+func (x *OverallPerformance) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverallPerformance.ProtoReflect.Descriptor instead.
+func (*OverallPerformance) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *OverallPerformance) GetAverageScore() float64 {
+	if x != nil {
+		return x.AverageScore
+	}
+	return 0
+}
+
+func (x *OverallPerformance) GetGradeDistribution() []*GradeDistributionItem {
+	if x != nil {
+		return x.GradeDistribution
+	}
+	return nil
+}
+
+func (x *OverallPerformance) GetSkillMasteryAnalysis() []*SkillMasteryItem {
+	if x != nil {
+		return x.SkillMasteryAnalysis
+	}
+	return nil
+}
+
+func (x *OverallPerformance) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+type GradeDistributionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grade         string                 `protobuf:"bytes,1,opt,name=grade,proto3" json:"grade,omitempty"`
+	StudentCount  int32                  `protobuf:"varint,2,opt,name=student_count,json=studentCount,proto3" json:"student_count,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GradeDistributionItem) Reset() {
+	*x = GradeDistributionItem{}
+	mi := &file_essay_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradeDistributionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradeDistributionItem) ProtoMessage() {}
+
+func (x *GradeDistributionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradeDistributionItem.ProtoReflect.Descriptor instead.
+func (*GradeDistributionItem) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GradeDistributionItem) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *GradeDistributionItem) GetStudentCount() int32 {
+	if x != nil {
+		return x.StudentCount
+	}
+	return 0
+}
+
+func (x *GradeDistributionItem) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type SkillMasteryItem struct {
+	state             protoimpl.MessageState   `protogen:"open.v1"`
+	SkillName         string                   `protobuf:"bytes,1,opt,name=skill_name,json=skillName,proto3" json:"skill_name,omitempty"`
+	GradeDistribution []*GradeDistributionItem `protobuf:"bytes,2,rep,name=grade_distribution,json=gradeDistribution,proto3" json:"grade_distribution,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SkillMasteryItem) Reset() {
+	*x = SkillMasteryItem{}
+	mi := &file_essay_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkillMasteryItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkillMasteryItem) ProtoMessage() {}
+
+func (x *SkillMasteryItem) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkillMasteryItem.ProtoReflect.Descriptor instead.
+func (*SkillMasteryItem) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SkillMasteryItem) GetSkillName() string {
+	if x != nil {
+		return x.SkillName
+	}
+	return ""
+}
+
+func (x *SkillMasteryItem) GetGradeDistribution() []*GradeDistributionItem {
+	if x != nil {
+		return x.GradeDistribution
+	}
+	return nil
+}
+
+type ErrorAnalysis struct {
+	state             protoimpl.MessageState   `protogen:"open.v1"`
+	ErrorDistribution []*ErrorDistributionItem `protobuf:"bytes,1,rep,name=error_distribution,json=errorDistribution,proto3" json:"error_distribution,omitempty"`
+	ErrorTypeRatio    []*ErrorTypeItem         `protobuf:"bytes,2,rep,name=error_type_ratio,json=errorTypeRatio,proto3" json:"error_type_ratio,omitempty"`
+	HighFrequencyList []*HighFrequencyError    `protobuf:"bytes,3,rep,name=high_frequency_list,json=highFrequencyList,proto3" json:"high_frequency_list,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ErrorAnalysis) Reset() {
+	*x = ErrorAnalysis{}
+	mi := &file_essay_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorAnalysis) ProtoMessage() {}
+
+func (x *ErrorAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorAnalysis.ProtoReflect.Descriptor instead.
+func (*ErrorAnalysis) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ErrorAnalysis) GetErrorDistribution() []*ErrorDistributionItem {
+	if x != nil {
+		return x.ErrorDistribution
+	}
+	return nil
+}
+
+func (x *ErrorAnalysis) GetErrorTypeRatio() []*ErrorTypeItem {
+	if x != nil {
+		return x.ErrorTypeRatio
+	}
+	return nil
+}
+
+func (x *ErrorAnalysis) GetHighFrequencyList() []*HighFrequencyError {
+	if x != nil {
+		return x.HighFrequencyList
+	}
+	return nil
+}
+
+type ErrorDistributionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorCount    string                 `protobuf:"bytes,1,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	StudentCount  int32                  `protobuf:"varint,2,opt,name=student_count,json=studentCount,proto3" json:"student_count,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDistributionItem) Reset() {
+	*x = ErrorDistributionItem{}
+	mi := &file_essay_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDistributionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDistributionItem) ProtoMessage() {}
+
+func (x *ErrorDistributionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDistributionItem.ProtoReflect.Descriptor instead.
+func (*ErrorDistributionItem) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ErrorDistributionItem) GetErrorCount() string {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return ""
+}
+
+func (x *ErrorDistributionItem) GetStudentCount() int32 {
+	if x != nil {
+		return x.StudentCount
+	}
+	return 0
+}
+
+func (x *ErrorDistributionItem) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type ErrorTypeItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorType     string                 `protobuf:"bytes,1,opt,name=error_type,json=errorType,proto3" json:"error_type,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	StudentCount  int32                  `protobuf:"varint,4,opt,name=student_count,json=studentCount,proto3" json:"student_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorTypeItem) Reset() {
+	*x = ErrorTypeItem{}
+	mi := &file_essay_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorTypeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorTypeItem) ProtoMessage() {}
+
+func (x *ErrorTypeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorTypeItem.ProtoReflect.Descriptor instead.
+func (*ErrorTypeItem) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ErrorTypeItem) GetErrorType() string {
+	if x != nil {
+		return x.ErrorType
+	}
+	return ""
+}
+
+func (x *ErrorTypeItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ErrorTypeItem) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *ErrorTypeItem) GetStudentCount() int32 {
+	if x != nil {
+		return x.StudentCount
+	}
+	return 0
+}
+
+type HighFrequencyError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorText     string                 `protobuf:"bytes,1,opt,name=error_text,json=errorText,proto3" json:"error_text,omitempty"`
+	ErrorType     string                 `protobuf:"bytes,2,opt,name=error_type,json=errorType,proto3" json:"error_type,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Examples      []string               `protobuf:"bytes,4,rep,name=examples,proto3" json:"examples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HighFrequencyError) Reset() {
+	*x = HighFrequencyError{}
+	mi := &file_essay_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighFrequencyError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighFrequencyError) ProtoMessage() {}
+
+func (x *HighFrequencyError) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighFrequencyError.ProtoReflect.Descriptor instead.
+func (*HighFrequencyError) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *HighFrequencyError) GetErrorText() string {
+	if x != nil {
+		return x.ErrorText
+	}
+	return ""
+}
+
+func (x *HighFrequencyError) GetErrorType() string {
+	if x != nil {
+		return x.ErrorType
+	}
+	return ""
+}
+
+func (x *HighFrequencyError) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *HighFrequencyError) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+type HighlightAnalysis struct {
+	state                 protoimpl.MessageState       `protogen:"open.v1"`
+	HighlightDistribution []*HighlightDistributionItem `protobuf:"bytes,1,rep,name=highlight_distribution,json=highlightDistribution,proto3" json:"highlight_distribution,omitempty"`
+	HighlightTypeRatio    []*HighlightTypeItem         `protobuf:"bytes,2,rep,name=highlight_type_ratio,json=highlightTypeRatio,proto3" json:"highlight_type_ratio,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *HighlightAnalysis) Reset() {
+	*x = HighlightAnalysis{}
+	mi := &file_essay_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightAnalysis) ProtoMessage() {}
+
+func (x *HighlightAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightAnalysis.ProtoReflect.Descriptor instead.
+func (*HighlightAnalysis) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *HighlightAnalysis) GetHighlightDistribution() []*HighlightDistributionItem {
+	if x != nil {
+		return x.HighlightDistribution
+	}
+	return nil
+}
+
+func (x *HighlightAnalysis) GetHighlightTypeRatio() []*HighlightTypeItem {
+	if x != nil {
+		return x.HighlightTypeRatio
+	}
+	return nil
+}
+
+type HighlightDistributionItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	HighlightCount string                 `protobuf:"bytes,1,opt,name=highlight_count,json=highlightCount,proto3" json:"highlight_count,omitempty"`
+	StudentCount   int32                  `protobuf:"varint,2,opt,name=student_count,json=studentCount,proto3" json:"student_count,omitempty"`
+	Percentage     float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HighlightDistributionItem) Reset() {
+	*x = HighlightDistributionItem{}
+	mi := &file_essay_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightDistributionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightDistributionItem) ProtoMessage() {}
+
+func (x *HighlightDistributionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightDistributionItem.ProtoReflect.Descriptor instead.
+func (*HighlightDistributionItem) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *HighlightDistributionItem) GetHighlightCount() string {
+	if x != nil {
+		return x.HighlightCount
+	}
+	return ""
+}
+
+func (x *HighlightDistributionItem) GetStudentCount() int32 {
+	if x != nil {
+		return x.StudentCount
+	}
+	return 0
+}
+
+func (x *HighlightDistributionItem) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type HighlightTypeItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HighlightType string                 `protobuf:"bytes,1,opt,name=highlight_type,json=highlightType,proto3" json:"highlight_type,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	StudentCount  int32                  `protobuf:"varint,4,opt,name=student_count,json=studentCount,proto3" json:"student_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HighlightTypeItem) Reset() {
+	*x = HighlightTypeItem{}
+	mi := &file_essay_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightTypeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightTypeItem) ProtoMessage() {}
+
+func (x *HighlightTypeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_essay_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightTypeItem.ProtoReflect.Descriptor instead.
+func (*HighlightTypeItem) Descriptor() ([]byte, []int) {
+	return file_essay_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *HighlightTypeItem) GetHighlightType() string {
+	if x != nil {
+		return x.HighlightType
+	}
+	return ""
+}
+
+func (x *HighlightTypeItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *HighlightTypeItem) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *HighlightTypeItem) GetStudentCount() int32 {
+	if x != nil {
+		return x.StudentCount
+	}
+	return 0
+}
+
+var File_essay_proto protoreflect.FileDescriptor
+
+const file_essay_proto_rawDesc = "" +
+	"\n" +
+	"\vessay.proto\x12\bessay.v1\"\xb9\x06\n" +
+	"\x0fEvaluateRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x19\n" +
+	"\x05grade\x18\x03 \x01(\x05H\x00R\x05grade\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"essay_type\x18\x04 \x01(\tH\x01R\tessayType\x88\x01\x01\x12$\n" +
+	"\vtotal_score\x18\x05 \x01(\x03H\x02R\n" +
+	"totalScore\x88\x01\x01\x12\x1b\n" +
+	"\x06prompt\x18\x06 \x01(\tH\x03R\x06prompt\x88\x01\x01\x12\x1f\n" +
+	"\bstandard\x18\a \x01(\tH\x04R\bstandard\x88\x01\x01\x12(\n" +
+	"\rcontent_score\x18\b \x01(\x03H\x05R\fcontentScore\x88\x01\x01\x12.\n" +
+	"\x10expression_score\x18\t \x01(\x03H\x06R\x0fexpressionScore\x88\x01\x01\x12,\n" +
+	"\x0fstructure_score\x18\n" +
+	" \x01(\x03H\aR\x0estructureScore\x88\x01\x01\x120\n" +
+	"\x11development_score\x18\v \x01(\x03H\bR\x10developmentScore\x88\x01\x01\x12-\n" +
+	"\x06rubric\x18\f \x01(\v2\x10.essay.v1.RubricH\tR\x06rubric\x88\x01\x01\x12 \n" +
+	"\trubric_id\x18\r \x01(\tH\n" +
+	"R\brubricId\x88\x01\x01\x12\x16\n" +
+	"\x06images\x18\x0e \x03(\tR\x06images\x12.\n" +
+	"\x10appearance_score\x18\x0f \x01(\x03H\vR\x0fappearanceScore\x88\x01\x01\x12,\n" +
+	"\x0freference_essay\x18\x10 \x01(\tH\fR\x0ereferenceEssay\x88\x01\x01B\b\n" +
+	"\x06_gradeB\r\n" +
+	"\v_essay_typeB\x0e\n" +
+	"\f_total_scoreB\t\n" +
+	"\a_promptB\v\n" +
+	"\t_standardB\x10\n" +
+	"\x0e_content_scoreB\x13\n" +
+	"\x11_expression_scoreB\x12\n" +
+	"\x10_structure_scoreB\x14\n" +
+	"\x12_development_scoreB\t\n" +
+	"\a_rubricB\f\n" +
+	"\n" +
+	"_rubric_idB\x13\n" +
+	"\x11_appearance_scoreB\x12\n" +
+	"\x10_reference_essay\"c\n" +
+	"\x06Rubric\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x125\n" +
+	"\bcriteria\x18\x03 \x03(\v2\x19.essay.v1.RubricCriterionR\bcriteria\"\xc3\x01\n" +
+	"\x0fRubricCriterion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tdimension\x18\x04 \x01(\tR\tdimension\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x05 \x01(\x03R\tmaxPoints\x12-\n" +
+	"\x06levels\x18\x06 \x03(\v2\x15.essay.v1.RubricLevelR\x06levels\"`\n" +
+	"\vRubricLevel\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"min_points\x18\x02 \x01(\x03R\tminPoints\x12\x1e\n" +
+	"\n" +
+	"descriptor\x18\x03 \x01(\tR\n" +
+	"descriptor\"\xb2\x03\n" +
+	"\x16StreamEvaluateResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\tR\x04step\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x05R\bprogress\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x1f\n" +
+	"\veta_seconds\x18\x06 \x01(\x05R\n" +
+	"etaSeconds\x12#\n" +
+	"\revaluation_id\x18\a \x01(\tR\fevaluationId\x12.\n" +
+	"\x04init\x18\n" +
+	" \x01(\v2\x18.essay.v1.StreamInitDataH\x00R\x04init\x125\n" +
+	"\tstep_data\x18\v \x01(\v2\x16.essay.v1.AIEvaluationH\x00R\bstepData\x124\n" +
+	"\x06result\x18\f \x01(\v2\x1a.essay.v1.EvaluateResponseH\x00R\x06result\x121\n" +
+	"\x05error\x18\r \x01(\v2\x19.essay.v1.StreamErrorDataH\x00R\x05errorB\x06\n" +
+	"\x04data\"\xdb\x02\n" +
+	"\x0eStreamInitData\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12'\n" +
+	"\x04text\x18\x02 \x03(\v2\x13.essay.v1.ParagraphR\x04text\x122\n" +
+	"\n" +
+	"essay_info\x18\x03 \x01(\v2\x13.essay.v1.EssayInfoR\tessayInfo\x12[\n" +
+	"\x16requirement_compliance\x18\x04 \x01(\v2\x1f.essay.v1.RequirementComplianceH\x00R\x15requirementCompliance\x88\x01\x01\x12I\n" +
+	"\x10genre_evaluation\x18\x05 \x01(\v2\x19.essay.v1.GenreEvaluationH\x01R\x0fgenreEvaluation\x88\x01\x01B\x19\n" +
+	"\x17_requirement_complianceB\x13\n" +
+	"\x11_genre_evaluation\"\x7f\n" +
+	"\x0fStreamErrorData\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\tR\x04step\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\")\n" +
+	"\tParagraph\x12\x1c\n" +
+	"\tsentences\x18\x01 \x03(\tR\tsentences\"\xc2\x01\n" +
+	"\x10EvaluateResponse\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12'\n" +
+	"\x04text\x18\x02 \x03(\v2\x13.essay.v1.ParagraphR\x04text\x122\n" +
+	"\n" +
+	"essay_info\x18\x03 \x01(\v2\x13.essay.v1.EssayInfoR\tessayInfo\x12;\n" +
+	"\rai_evaluation\x18\x04 \x01(\v2\x16.essay.v1.AIEvaluationR\faiEvaluation\"\xe2\x01\n" +
+	"\tEssayInfo\x12\x1d\n" +
+	"\n" +
+	"essay_type\x18\x01 \x01(\tR\tessayType\x12\x14\n" +
+	"\x05grade\x18\x02 \x01(\x05R\x05grade\x12.\n" +
+	"\bcounting\x18\x03 \x01(\v2\x12.essay.v1.CountingR\bcounting\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x03R\x05score\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12 \n" +
+	"\vapproximate\x18\x06 \x03(\tR\vapproximate\x12 \n" +
+	"\vunavailable\x18\a \x03(\tR\vunavailable\"\xea\x03\n" +
+	"\bCounting\x12\x1e\n" +
+	"\vadj_adv_num\x18\x01 \x01(\x05R\tadjAdvNum\x12\x19\n" +
+	"\bchar_num\x18\x02 \x01(\x05R\acharNum\x12\x1b\n" +
+	"\tdieci_num\x18\x03 \x01(\x05R\bdieciNum\x12\x18\n" +
+	"\afluency\x18\x04 \x01(\x05R\afluency\x12.\n" +
+	"\x13grammar_mistake_num\x18\x05 \x01(\x05R\x11grammarMistakeNum\x12.\n" +
+	"\x13highlight_sents_num\x18\x06 \x01(\x05R\x11highlightSentsNum\x12\x1b\n" +
+	"\tidiom_num\x18\a \x01(\x05R\bidiomNum\x12\"\n" +
+	"\rnoun_type_num\x18\b \x01(\x05R\vnounTypeNum\x12\x19\n" +
+	"\bpara_num\x18\t \x01(\x05R\aparaNum\x12\x19\n" +
+	"\bsent_num\x18\n" +
+	" \x01(\x05R\asentNum\x12&\n" +
+	"\x0funique_word_num\x18\v \x01(\x05R\runiqueWordNum\x12\"\n" +
+	"\rverb_type_num\x18\f \x01(\x05R\vverbTypeNum\x12\x19\n" +
+	"\bword_num\x18\r \x01(\x05R\awordNum\x12.\n" +
+	"\x13written_mistake_num\x18\x0e \x01(\x05R\x11writtenMistakeNum\"\xfc\x06\n" +
+	"\fAIEvaluation\x12;\n" +
+	"\rmodel_version\x18\x01 \x01(\v2\x16.essay.v1.ModelVersionR\fmodelVersion\x12J\n" +
+	"\x12overall_evaluation\x18\x02 \x01(\v2\x1b.essay.v1.OverallEvaluationR\x11overallEvaluation\x12Z\n" +
+	"\x18word_sentence_evaluation\x18\x03 \x01(\v2 .essay.v1.WordSentenceEvaluationR\x16wordSentenceEvaluation\x12S\n" +
+	"\x15suggestion_evaluation\x18\x04 \x01(\v2\x1e.essay.v1.SuggestionEvaluationR\x14suggestionEvaluation\x12R\n" +
+	"\x15paragraph_evaluations\x18\x05 \x03(\v2\x1d.essay.v1.ParagraphEvaluationR\x14paragraphEvaluations\x12D\n" +
+	"\x10score_evaluation\x18\x06 \x01(\v2\x19.essay.v1.ScoreEvaluationR\x0fscoreEvaluation\x12P\n" +
+	"\x14polishing_evaluation\x18\a \x03(\v2\x1d.essay.v1.PolishingEvaluationR\x13polishingEvaluation\x12[\n" +
+	"\x16requirement_compliance\x18\b \x01(\v2\x1f.essay.v1.RequirementComplianceH\x00R\x15requirementCompliance\x88\x01\x01\x12I\n" +
+	"\x10genre_evaluation\x18\t \x01(\v2\x19.essay.v1.GenreEvaluationH\x01R\x0fgenreEvaluation\x88\x01\x01\x12U\n" +
+	"\x14reference_comparison\x18\n" +
+	" \x01(\v2\x1d.essay.v1.ReferenceComparisonH\x02R\x13referenceComparison\x88\x01\x01B\x19\n" +
+	"\x17_requirement_complianceB\x13\n" +
+	"\x11_genre_evaluationB\x17\n" +
+	"\x15_reference_comparison\"\xff\x01\n" +
+	"\x13ReferenceComparison\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x01 \x01(\x01R\n" +
+	"similarity\x12;\n" +
+	"\tstructure\x18\x02 \x01(\v2\x1d.essay.v1.StructureComparisonR\tstructure\x12%\n" +
+	"\x0eshared_phrases\x18\x03 \x03(\tR\rsharedPhrases\x122\n" +
+	"\acovered\x18\x04 \x03(\v2\x18.essay.v1.ReferencePointR\acovered\x120\n" +
+	"\x06missed\x18\x05 \x03(\v2\x18.essay.v1.ReferencePointR\x06missed\"\xda\x02\n" +
+	"\x13StructureComparison\x12'\n" +
+	"\x0fparagraph_count\x18\x01 \x01(\x05R\x0eparagraphCount\x12:\n" +
+	"\x19reference_paragraph_count\x18\x02 \x01(\x05R\x17referenceParagraphCount\x12\x1d\n" +
+	"\n" +
+	"char_count\x18\x03 \x01(\x05R\tcharCount\x120\n" +
+	"\x14reference_char_count\x18\x04 \x01(\x05R\x12referenceCharCount\x12+\n" +
+	"\x11paragraph_lengths\x18\x05 \x03(\x05R\x10paragraphLengths\x12>\n" +
+	"\x1breference_paragraph_lengths\x18\x06 \x03(\x05R\x19referenceParagraphLengths\x12 \n" +
+	"\vdifferences\x18\a \x03(\tR\vdifferences\"\xcc\x01\n" +
+	"\x0eReferencePoint\x12:\n" +
+	"\x19reference_paragraph_index\x18\x01 \x01(\x05R\x17referenceParagraphIndex\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12\x1f\n" +
+	"\vkey_phrases\x18\x03 \x03(\tR\n" +
+	"keyPhrases\x12\x1a\n" +
+	"\bcoverage\x18\x04 \x01(\x01R\bcoverage\x12'\n" +
+	"\x0fparagraph_index\x18\x05 \x01(\x05R\x0eparagraphIndex\"x\n" +
+	"\x0fGenreEvaluation\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x1d\n" +
+	"\n" +
+	"essay_type\x18\x02 \x01(\tR\tessayType\x12,\n" +
+	"\x06checks\x18\x03 \x03(\v2\x14.essay.v1.GenreCheckR\x06checks\"\x97\x01\n" +
+	"\n" +
+	"GenreCheck\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06passed\x18\x03 \x01(\bR\x06passed\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\x121\n" +
+	"\bevidence\x18\x05 \x03(\v2\x15.essay.v1.EvidenceRefR\bevidence\"p\n" +
+	"\x15RequirementCompliance\x12\x17\n" +
+	"\aall_met\x18\x01 \x01(\bR\x06allMet\x12>\n" +
+	"\frequirements\x18\x02 \x03(\v2\x1a.essay.v1.RequirementCheckR\frequirements\"v\n" +
+	"\x10RequirementCheck\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12 \n" +
+	"\vrequirement\x18\x02 \x01(\tR\vrequirement\x12\x10\n" +
+	"\x03met\x18\x03 \x01(\bR\x03met\x12\x1a\n" +
+	"\bevidence\x18\x04 \x01(\tR\bevidence\"<\n" +
+	"\fModelVersion\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"i\n" +
+	"\x11OverallEvaluation\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x122\n" +
+	"\x15topic_relevance_score\x18\x02 \x01(\x05R\x13topicRelevanceScore\"\xa3\x01\n" +
+	"\x16WordSentenceEvaluation\x12Y\n" +
+	"\x14sentence_evaluations\x18\x01 \x03(\v2&.essay.v1.ParagraphSentenceEvaluationsR\x13sentenceEvaluations\x12.\n" +
+	"\x13word_sentence_score\x18\x02 \x01(\x05R\x11wordSentenceScore\"Z\n" +
+	"\x1cParagraphSentenceEvaluations\x12:\n" +
+	"\tsentences\x18\x01 \x03(\v2\x1c.essay.v1.SentenceEvaluationR\tsentences\"\x8e\x02\n" +
+	"\x12SentenceEvaluation\x12(\n" +
+	"\x10is_good_sentence\x18\x01 \x01(\bR\x0eisGoodSentence\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12:\n" +
+	"\x04type\x18\x03 \x03(\v2&.essay.v1.SentenceEvaluation.TypeEntryR\x04type\x12C\n" +
+	"\x10word_evaluations\x18\x04 \x03(\v2\x18.essay.v1.WordEvaluationR\x0fwordEvaluations\x1a7\n" +
+	"\tTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc1\x01\n" +
+	"\x0eWordEvaluation\x12\x12\n" +
+	"\x04span\x18\x01 \x03(\x05R\x04span\x126\n" +
+	"\x04type\x18\x02 \x03(\v2\".essay.v1.WordEvaluation.TypeEntryR\x04type\x12\x10\n" +
+	"\x03ori\x18\x03 \x01(\tR\x03ori\x12\x18\n" +
+	"\arevised\x18\x04 \x01(\tR\arevised\x1a7\n" +
+	"\tTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"M\n" +
+	"\x14SuggestionEvaluation\x125\n" +
+	"\x16suggestion_description\x18\x01 \x01(\tR\x15suggestionDescription\"X\n" +
+	"\x13ParagraphEvaluation\x12'\n" +
+	"\x0fparagraph_index\x18\x01 \x01(\x05R\x0eparagraphIndex\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"\xf6\x04\n" +
+	"\x0fScoreEvaluation\x12\x18\n" +
+	"\acomment\x18\x01 \x01(\tR\acomment\x12.\n" +
+	"\bcomments\x18\x02 \x01(\v2\x12.essay.v1.CommentsR\bcomments\x12(\n" +
+	"\x06scores\x18\x03 \x01(\v2\x10.essay.v1.ScoresR\x06scores\x12>\n" +
+	"\texam_band\x18\x04 \x01(\v2\x1c.essay.v1.ExamBandEvaluationH\x00R\bexamBand\x88\x01\x01\x124\n" +
+	"\bcriteria\x18\x05 \x03(\v2\x18.essay.v1.CriterionScoreR\bcriteria\x12;\n" +
+	"\vadjustments\x18\x06 \x03(\v2\x19.essay.v1.ScoreAdjustmentR\vadjustments\x12\x1b\n" +
+	"\toff_topic\x18\a \x01(\bR\boffTopic\x12(\n" +
+	"\x10off_topic_reason\x18\b \x01(\tR\x0eoffTopicReason\x12>\n" +
+	"\n" +
+	"confidence\x18\t \x01(\v2\x19.essay.v1.ScoreConfidenceH\x01R\n" +
+	"confidence\x88\x01\x01\x12C\n" +
+	"\bevidence\x18\n" +
+	" \x03(\v2'.essay.v1.ScoreEvaluation.EvidenceEntryR\bevidence\x1aS\n" +
+	"\rEvidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.essay.v1.EvidenceListR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_exam_bandB\r\n" +
+	"\v_confidence\"9\n" +
+	"\fEvidenceList\x12)\n" +
+	"\x04refs\x18\x01 \x03(\v2\x15.essay.v1.EvidenceRefR\x04refs\"\xcd\x01\n" +
+	"\vEvidenceRef\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x1a\n" +
+	"\bpolarity\x18\x02 \x01(\tR\bpolarity\x12'\n" +
+	"\x0fparagraph_index\x18\x03 \x01(\x05R\x0eparagraphIndex\x12%\n" +
+	"\x0esentence_index\x18\x04 \x01(\x05R\rsentenceIndex\x12\x12\n" +
+	"\x04span\x18\x05 \x03(\x05R\x04span\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\"\xcb\x01\n" +
+	"\x0fScoreConfidence\x12\x12\n" +
+	"\x04runs\x18\x01 \x01(\x05R\x04runs\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12\x1c\n" +
+	"\taggregate\x18\x03 \x01(\tR\taggregate\x12/\n" +
+	"\aspreads\x18\x04 \x03(\v2\x15.essay.v1.ScoreSpreadR\aspreads\x12%\n" +
+	"\x0elow_confidence\x18\x05 \x01(\bR\rlowConfidence\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\x8c\x01\n" +
+	"\vScoreSpread\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x03R\x06values\x12\x1e\n" +
+	"\n" +
+	"aggregated\x18\x03 \x01(\x03R\n" +
+	"aggregated\x12\x16\n" +
+	"\x06spread\x18\x04 \x01(\x03R\x06spread\x12\x17\n" +
+	"\astd_dev\x18\x05 \x01(\x01R\x06stdDev\"\x8b\x01\n" +
+	"\x0fScoreAdjustment\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\boriginal\x18\x03 \x01(\x03R\boriginal\x12\x1a\n" +
+	"\badjusted\x18\x04 \x01(\x03R\badjusted\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\x93\x02\n" +
+	"\x0eCriterionScore\x12!\n" +
+	"\fcriterion_id\x18\x01 \x01(\tR\vcriterionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tdimension\x18\x03 \x01(\tR\tdimension\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x03R\x05score\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x05 \x01(\x03R\tmaxPoints\x12\x14\n" +
+	"\x05level\x18\x06 \x01(\tR\x05level\x12)\n" +
+	"\x10level_descriptor\x18\a \x01(\tR\x0flevelDescriptor\x12\x18\n" +
+	"\acomment\x18\b \x01(\tR\acomment\x12\x1c\n" +
+	"\testimated\x18\t \x01(\bR\testimated\"\xcc\x01\n" +
+	"\x12ExamBandEvaluation\x12\x1a\n" +
+	"\bstandard\x18\x01 \x01(\tR\bstandard\x12#\n" +
+	"\rstandard_name\x18\x02 \x01(\tR\fstandardName\x12-\n" +
+	"\x04band\x18\x03 \x01(\v2\x14.essay.v1.BandResultH\x00R\x04band\x88\x01\x01\x12=\n" +
+	"\n" +
+	"dimensions\x18\x04 \x03(\v2\x1d.essay.v1.DimensionBandResultR\n" +
+	"dimensionsB\a\n" +
+	"\x05_band\"\x9c\x01\n" +
+	"\n" +
+	"BandResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"descriptor\x18\x02 \x01(\tR\n" +
+	"descriptor\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05ratio\x18\x05 \x01(\x01R\x05ratio\x12\x18\n" +
+	"\areasons\x18\x06 \x03(\tR\areasons\"s\n" +
+	"\x13DimensionBandResult\x12\x1c\n" +
+	"\tdimension\x18\x01 \x01(\tR\tdimension\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12(\n" +
+	"\x04band\x18\x03 \x01(\v2\x14.essay.v1.BandResultR\x04band\"\xa4\x01\n" +
+	"\bComments\x12\x1e\n" +
+	"\n" +
+	"appearance\x18\x01 \x01(\tR\n" +
+	"appearance\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x03 \x01(\tR\n" +
+	"expression\x12\x1c\n" +
+	"\tstructure\x18\x04 \x01(\tR\tstructure\x12 \n" +
+	"\vdevelopment\x18\x05 \x01(\tR\vdevelopment\"\xd8\x03\n" +
+	"\x06Scores\x12\x10\n" +
+	"\x03all\x18\x01 \x01(\x03R\x03all\x12\x1e\n" +
+	"\n" +
+	"appearance\x18\x02 \x01(\x03R\n" +
+	"appearance\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\x03R\acontent\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x04 \x01(\x03R\n" +
+	"expression\x12\x1c\n" +
+	"\tstructure\x18\x05 \x01(\x03R\tstructure\x12 \n" +
+	"\vdevelopment\x18\x06 \x01(\x03R\vdevelopment\x12$\n" +
+	"\x0eall_with_total\x18\a \x01(\tR\fallWithTotal\x12,\n" +
+	"\x12content_with_total\x18\b \x01(\tR\x10contentWithTotal\x122\n" +
+	"\x15expression_with_total\x18\t \x01(\tR\x13expressionWithTotal\x120\n" +
+	"\x14structure_with_total\x18\n" +
+	" \x01(\tR\x12structureWithTotal\x124\n" +
+	"\x16development_with_total\x18\v \x01(\tR\x14developmentWithTotal\x122\n" +
+	"\x15appearance_with_total\x18\f \x01(\tR\x13appearanceWithTotal\"m\n" +
+	"\x13PolishingEvaluation\x12'\n" +
+	"\x0fparagraph_index\x18\x01 \x01(\x05R\x0eparagraphIndex\x12-\n" +
+	"\x05edits\x18\x02 \x03(\v2\x17.essay.v1.PolishingEditR\x05edits\"\xa8\x01\n" +
+	"\rPolishingEdit\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1a\n" +
+	"\boriginal\x18\x03 \x01(\tR\boriginal\x12\x18\n" +
+	"\arevised\x18\x04 \x01(\tR\arevised\x12%\n" +
+	"\x0esentence_index\x18\x05 \x01(\x05R\rsentenceIndex\x12\x12\n" +
+	"\x04span\x18\x06 \x03(\x05R\x04span\"\x90\x01\n" +
+	"\x0fTitleOcrRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bimg_type\x18\x02 \x01(\tR\aimgType\x12\x16\n" +
+	"\x06images\x18\x03 \x03(\tR\x06images\x12 \n" +
+	"\tleft_type\x18\x04 \x01(\tH\x00R\bleftType\x88\x01\x01B\f\n" +
+	"\n" +
+	"_left_type\"B\n" +
+	"\x10TitleOcrResponse\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xb5\x01\n" +
+	"\x11StatisticsRequest\x12Z\n" +
+	"\x18word_sentence_evaluation\x18\x01 \x01(\v2 .essay.v1.WordSentenceEvaluationR\x16wordSentenceEvaluation\x12D\n" +
+	"\x10score_evaluation\x18\x02 \x01(\v2\x19.essay.v1.ScoreEvaluationR\x0fscoreEvaluation\"\x8b\x01\n" +
+	"\x16ClassStatisticsRequest\x12J\n" +
+	"\x12submitted_students\x18\x01 \x03(\v2\x1b.essay.v1.StatisticsRequestR\x11submittedStudents\x12%\n" +
+	"\x0etotal_students\x18\x02 \x01(\x05R\rtotalStudents\"\xd0\x02\n" +
+	"\x17ClassStatisticsResponse\x123\n" +
+	"\x15submission_percentage\x18\x01 \x01(\x01R\x14submissionPercentage\x12M\n" +
+	"\x13overall_performance\x18\x02 \x01(\v2\x1c.essay.v1.OverallPerformanceR\x12overallPerformance\x12>\n" +
+	"\x0eerror_analysis\x18\x03 \x01(\v2\x17.essay.v1.ErrorAnalysisR\rerrorAnalysis\x12J\n" +
+	"\x12highlight_analysis\x18\x04 \x01(\v2\x1b.essay.v1.HighlightAnalysisR\x11highlightAnalysis\x12%\n" +
+	"\x0egenerated_time\x18\x05 \x01(\x03R\rgeneratedTime\"\xf5\x01\n" +
+	"\x12OverallPerformance\x12#\n" +
+	"\raverage_score\x18\x01 \x01(\x01R\faverageScore\x12N\n" +
+	"\x12grade_distribution\x18\x02 \x03(\v2\x1f.essay.v1.GradeDistributionItemR\x11gradeDistribution\x12P\n" +
+	"\x16skill_mastery_analysis\x18\x03 \x03(\v2\x1a.essay.v1.SkillMasteryItemR\x14skillMasteryAnalysis\x12\x18\n" +
+	"\asummary\x18\x04 \x01(\tR\asummary\"r\n" +
+	"\x15GradeDistributionItem\x12\x14\n" +
+	"\x05grade\x18\x01 \x01(\tR\x05grade\x12#\n" +
+	"\rstudent_count\x18\x02 \x01(\x05R\fstudentCount\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\"\x81\x01\n" +
+	"\x10SkillMasteryItem\x12\x1d\n" +
+	"\n" +
+	"skill_name\x18\x01 \x01(\tR\tskillName\x12N\n" +
+	"\x12grade_distribution\x18\x02 \x03(\v2\x1f.essay.v1.GradeDistributionItemR\x11gradeDistribution\"\xf0\x01\n" +
+	"\rErrorAnalysis\x12N\n" +
+	"\x12error_distribution\x18\x01 \x03(\v2\x1f.essay.v1.ErrorDistributionItemR\x11errorDistribution\x12A\n" +
+	"\x10error_type_ratio\x18\x02 \x03(\v2\x17.essay.v1.ErrorTypeItemR\x0eerrorTypeRatio\x12L\n" +
+	"\x13high_frequency_list\x18\x03 \x03(\v2\x1c.essay.v1.HighFrequencyErrorR\x11highFrequencyList\"}\n" +
+	"\x15ErrorDistributionItem\x12\x1f\n" +
+	"\verror_count\x18\x01 \x01(\tR\n" +
+	"errorCount\x12#\n" +
+	"\rstudent_count\x18\x02 \x01(\x05R\fstudentCount\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\"\x89\x01\n" +
+	"\rErrorTypeItem\x12\x1d\n" +
+	"\n" +
+	"error_type\x18\x01 \x01(\tR\terrorType\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\x12#\n" +
+	"\rstudent_count\x18\x04 \x01(\x05R\fstudentCount\"\x84\x01\n" +
+	"\x12HighFrequencyError\x12\x1d\n" +
+	"\n" +
+	"error_text\x18\x01 \x01(\tR\terrorText\x12\x1d\n" +
+	"\n" +
+	"error_type\x18\x02 \x01(\tR\terrorType\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1a\n" +
+	"\bexamples\x18\x04 \x03(\tR\bexamples\"\xbe\x01\n" +
+	"\x11HighlightAnalysis\x12Z\n" +
+	"\x16highlight_distribution\x18\x01 \x03(\v2#.essay.v1.HighlightDistributionItemR\x15highlightDistribution\x12M\n" +
+	"\x14highlight_type_ratio\x18\x02 \x03(\v2\x1b.essay.v1.HighlightTypeItemR\x12highlightTypeRatio\"\x89\x01\n" +
+	"\x19HighlightDistributionItem\x12'\n" +
+	"\x0fhighlight_count\x18\x01 \x01(\tR\x0ehighlightCount\x12#\n" +
+	"\rstudent_count\x18\x02 \x01(\x05R\fstudentCount\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\"\x95\x01\n" +
+	"\x11HighlightTypeItem\x12%\n" +
+	"\x0ehighlight_type\x18\x01 \x01(\tR\rhighlightType\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\x12#\n" +
+	"\rstudent_count\x18\x04 \x01(\x05R\fstudentCount2\x81\x02\n" +
+	"\fEssayService\x12O\n" +
+	"\x0eEvaluateStream\x12\x19.essay.v1.EvaluateRequest\x1a .essay.v1.StreamEvaluateResponse0\x01\x12A\n" +
+	"\bTitleOcr\x12\x19.essay.v1.TitleOcrRequest\x1a\x1a.essay.v1.TitleOcrResponse\x12]\n" +
+	"\x16AnalyzeClassStatistics\x12 .essay.v1.ClassStatisticsRequest\x1a!.essay.v1.ClassStatisticsResponseB&Z$essay-stateless/api/essay/v1;essayv1b\x06proto3"
+
+var (
+	file_essay_proto_rawDescOnce sync.Once
+	file_essay_proto_rawDescData []byte
+)
+
+func file_essay_proto_rawDescGZIP() []byte {
+	file_essay_proto_rawDescOnce.Do(func() {
+		file_essay_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_essay_proto_rawDesc), len(file_essay_proto_rawDesc)))
+	})
+	return file_essay_proto_rawDescData
+}
+
+var file_essay_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
+var file_essay_proto_goTypes = []any{
+	(*EvaluateRequest)(nil),              // 0: essay.v1.EvaluateRequest
+	(*Rubric)(nil),                       // 1: essay.v1.Rubric
+	(*RubricCriterion)(nil),              // 2: essay.v1.RubricCriterion
+	(*RubricLevel)(nil),                  // 3: essay.v1.RubricLevel
+	(*StreamEvaluateResponse)(nil),       // 4: essay.v1.StreamEvaluateResponse
+	(*StreamInitData)(nil),               // 5: essay.v1.StreamInitData
+	(*StreamErrorData)(nil),              // 6: essay.v1.StreamErrorData
+	(*Paragraph)(nil),                    // 7: essay.v1.Paragraph
+	(*EvaluateResponse)(nil),             // 8: essay.v1.EvaluateResponse
+	(*EssayInfo)(nil),                    // 9: essay.v1.EssayInfo
+	(*Counting)(nil),                     // 10: essay.v1.Counting
+	(*AIEvaluation)(nil),                 // 11: essay.v1.AIEvaluation
+	(*ReferenceComparison)(nil),          // 12: essay.v1.ReferenceComparison
+	(*StructureComparison)(nil),          // 13: essay.v1.StructureComparison
+	(*ReferencePoint)(nil),               // 14: essay.v1.ReferencePoint
+	(*GenreEvaluation)(nil),              // 15: essay.v1.GenreEvaluation
+	(*GenreCheck)(nil),                   // 16: essay.v1.GenreCheck
+	(*RequirementCompliance)(nil),        // 17: essay.v1.RequirementCompliance
+	(*RequirementCheck)(nil),             // 18: essay.v1.RequirementCheck
+	(*ModelVersion)(nil),                 // 19: essay.v1.ModelVersion
+	(*OverallEvaluation)(nil),            // 20: essay.v1.OverallEvaluation
+	(*WordSentenceEvaluation)(nil),       // 21: essay.v1.WordSentenceEvaluation
+	(*ParagraphSentenceEvaluations)(nil), // 22: essay.v1.ParagraphSentenceEvaluations
+	(*SentenceEvaluation)(nil),           // 23: essay.v1.SentenceEvaluation
+	(*WordEvaluation)(nil),               // 24: essay.v1.WordEvaluation
+	(*SuggestionEvaluation)(nil),         // 25: essay.v1.SuggestionEvaluation
+	(*ParagraphEvaluation)(nil),          // 26: essay.v1.ParagraphEvaluation
+	(*ScoreEvaluation)(nil),              // 27: essay.v1.ScoreEvaluation
+	(*EvidenceList)(nil),                 // 28: essay.v1.EvidenceList
+	(*EvidenceRef)(nil),                  // 29: essay.v1.EvidenceRef
+	(*ScoreConfidence)(nil),              // 30: essay.v1.ScoreConfidence
+	(*ScoreSpread)(nil),                  // 31: essay.v1.ScoreSpread
+	(*ScoreAdjustment)(nil),              // 32: essay.v1.ScoreAdjustment
+	(*CriterionScore)(nil),               // 33: essay.v1.CriterionScore
+	(*ExamBandEvaluation)(nil),           // 34: essay.v1.ExamBandEvaluation
+	(*BandResult)(nil),                   // 35: essay.v1.BandResult
+	(*DimensionBandResult)(nil),          // 36: essay.v1.DimensionBandResult
+	(*Comments)(nil),                     // 37: essay.v1.Comments
+	(*Scores)(nil),                       // 38: essay.v1.Scores
+	(*PolishingEvaluation)(nil),          // 39: essay.v1.PolishingEvaluation
+	(*PolishingEdit)(nil),                // 40: essay.v1.PolishingEdit
+	(*TitleOcrRequest)(nil),              // 41: essay.v1.TitleOcrRequest
+	(*TitleOcrResponse)(nil),             // 42: essay.v1.TitleOcrResponse
+	(*StatisticsRequest)(nil),            // 43: essay.v1.StatisticsRequest
+	(*ClassStatisticsRequest)(nil),       // 44: essay.v1.ClassStatisticsRequest
+	(*ClassStatisticsResponse)(nil),      // 45: essay.v1.ClassStatisticsResponse
+	(*OverallPerformance)(nil),           // 46: essay.v1.OverallPerformance
+	(*GradeDistributionItem)(nil),        // 47: essay.v1.GradeDistributionItem
+	(*SkillMasteryItem)(nil),             // 48: essay.v1.SkillMasteryItem
+	(*ErrorAnalysis)(nil),                // 49: essay.v1.ErrorAnalysis
+	(*ErrorDistributionItem)(nil),        // 50: essay.v1.ErrorDistributionItem
+	(*ErrorTypeItem)(nil),                // 51: essay.v1.ErrorTypeItem
+	(*HighFrequencyError)(nil),           // 52: essay.v1.HighFrequencyError
+	(*HighlightAnalysis)(nil),            // 53: essay.v1.HighlightAnalysis
+	(*HighlightDistributionItem)(nil),    // 54: essay.v1.HighlightDistributionItem
+	(*HighlightTypeItem)(nil),            // 55: essay.v1.HighlightTypeItem
+	nil,                                  // 56: essay.v1.SentenceEvaluation.TypeEntry
+	nil,                                  // 57: essay.v1.WordEvaluation.TypeEntry
+	nil,                                  // 58: essay.v1.ScoreEvaluation.EvidenceEntry
+}
+var file_essay_proto_depIdxs = []int32{
+	1,  // 0: essay.v1.EvaluateRequest.rubric:type_name -> essay.v1.Rubric
+	2,  // 1: essay.v1.Rubric.criteria:type_name -> essay.v1.RubricCriterion
+	3,  // 2: essay.v1.RubricCriterion.levels:type_name -> essay.v1.RubricLevel
+	5,  // 3: essay.v1.StreamEvaluateResponse.init:type_name -> essay.v1.StreamInitData
+	11, // 4: essay.v1.StreamEvaluateResponse.step_data:type_name -> essay.v1.AIEvaluation
+	8,  // 5: essay.v1.StreamEvaluateResponse.result:type_name -> essay.v1.EvaluateResponse
+	6,  // 6: essay.v1.StreamEvaluateResponse.error:type_name -> essay.v1.StreamErrorData
+	7,  // 7: essay.v1.StreamInitData.text:type_name -> essay.v1.Paragraph
+	9,  // 8: essay.v1.StreamInitData.essay_info:type_name -> essay.v1.EssayInfo
+	17, // 9: essay.v1.StreamInitData.requirement_compliance:type_name -> essay.v1.RequirementCompliance
+	15, // 10: essay.v1.StreamInitData.genre_evaluation:type_name -> essay.v1.GenreEvaluation
+	7,  // 11: essay.v1.EvaluateResponse.text:type_name -> essay.v1.Paragraph
+	9,  // 12: essay.v1.EvaluateResponse.essay_info:type_name -> essay.v1.EssayInfo
+	11, // 13: essay.v1.EvaluateResponse.ai_evaluation:type_name -> essay.v1.AIEvaluation
+	10, // 14: essay.v1.EssayInfo.counting:type_name -> essay.v1.Counting
+	19, // 15: essay.v1.AIEvaluation.model_version:type_name -> essay.v1.ModelVersion
+	20, // 16: essay.v1.AIEvaluation.overall_evaluation:type_name -> essay.v1.OverallEvaluation
+	21, // 17: essay.v1.AIEvaluation.word_sentence_evaluation:type_name -> essay.v1.WordSentenceEvaluation
+	25, // 18: essay.v1.AIEvaluation.suggestion_evaluation:type_name -> essay.v1.SuggestionEvaluation
+	26, // 19: essay.v1.AIEvaluation.paragraph_evaluations:type_name -> essay.v1.ParagraphEvaluation
+	27, // 20: essay.v1.AIEvaluation.score_evaluation:type_name -> essay.v1.ScoreEvaluation
+	39, // 21: essay.v1.AIEvaluation.polishing_evaluation:type_name -> essay.v1.PolishingEvaluation
+	17, // 22: essay.v1.AIEvaluation.requirement_compliance:type_name -> essay.v1.RequirementCompliance
+	15, // 23: essay.v1.AIEvaluation.genre_evaluation:type_name -> essay.v1.GenreEvaluation
+	12, // 24: essay.v1.AIEvaluation.reference_comparison:type_name -> essay.v1.ReferenceComparison
+	13, // 25: essay.v1.ReferenceComparison.structure:type_name -> essay.v1.StructureComparison
+	14, // 26: essay.v1.ReferenceComparison.covered:type_name -> essay.v1.ReferencePoint
+	14, // 27: essay.v1.ReferenceComparison.missed:type_name -> essay.v1.ReferencePoint
+	16, // 28: essay.v1.GenreEvaluation.checks:type_name -> essay.v1.GenreCheck
+	29, // 29: essay.v1.GenreCheck.evidence:type_name -> essay.v1.EvidenceRef
+	18, // 30: essay.v1.RequirementCompliance.requirements:type_name -> essay.v1.RequirementCheck
+	22, // 31: essay.v1.WordSentenceEvaluation.sentence_evaluations:type_name -> essay.v1.ParagraphSentenceEvaluations
+	23, // 32: essay.v1.ParagraphSentenceEvaluations.sentences:type_name -> essay.v1.SentenceEvaluation
+	56, // 33: essay.v1.SentenceEvaluation.type:type_name -> essay.v1.SentenceEvaluation.TypeEntry
+	24, // 34: essay.v1.SentenceEvaluation.word_evaluations:type_name -> essay.v1.WordEvaluation
+	57, // 35: essay.v1.WordEvaluation.type:type_name -> essay.v1.WordEvaluation.TypeEntry
+	37, // 36: essay.v1.ScoreEvaluation.comments:type_name -> essay.v1.Comments
+	38, // 37: essay.v1.ScoreEvaluation.scores:type_name -> essay.v1.Scores
+	34, // 38: essay.v1.ScoreEvaluation.exam_band:type_name -> essay.v1.ExamBandEvaluation
+	33, // 39: essay.v1.ScoreEvaluation.criteria:type_name -> essay.v1.CriterionScore
+	32, // 40: essay.v1.ScoreEvaluation.adjustments:type_name -> essay.v1.ScoreAdjustment
+	30, // 41: essay.v1.ScoreEvaluation.confidence:type_name -> essay.v1.ScoreConfidence
+	58, // 42: essay.v1.ScoreEvaluation.evidence:type_name -> essay.v1.ScoreEvaluation.EvidenceEntry
+	29, // 43: essay.v1.EvidenceList.refs:type_name -> essay.v1.EvidenceRef
+	31, // 44: essay.v1.ScoreConfidence.spreads:type_name -> essay.v1.ScoreSpread
+	35, // 45: essay.v1.ExamBandEvaluation.band:type_name -> essay.v1.BandResult
+	36, // 46: essay.v1.ExamBandEvaluation.dimensions:type_name -> essay.v1.DimensionBandResult
+	35, // 47: essay.v1.DimensionBandResult.band:type_name -> essay.v1.BandResult
+	40, // 48: essay.v1.PolishingEvaluation.edits:type_name -> essay.v1.PolishingEdit
+	21, // 49: essay.v1.StatisticsRequest.word_sentence_evaluation:type_name -> essay.v1.WordSentenceEvaluation
+	27, // 50: essay.v1.StatisticsRequest.score_evaluation:type_name -> essay.v1.ScoreEvaluation
+	43, // 51: essay.v1.ClassStatisticsRequest.submitted_students:type_name -> essay.v1.StatisticsRequest
+	46, // 52: essay.v1.ClassStatisticsResponse.overall_performance:type_name -> essay.v1.OverallPerformance
+	49, // 53: essay.v1.ClassStatisticsResponse.error_analysis:type_name -> essay.v1.ErrorAnalysis
+	53, // 54: essay.v1.ClassStatisticsResponse.highlight_analysis:type_name -> essay.v1.HighlightAnalysis
+	47, // 55: essay.v1.OverallPerformance.grade_distribution:type_name -> essay.v1.GradeDistributionItem
+	48, // 56: essay.v1.OverallPerformance.skill_mastery_analysis:type_name -> essay.v1.SkillMasteryItem
+	47, // 57: essay.v1.SkillMasteryItem.grade_distribution:type_name -> essay.v1.GradeDistributionItem
+	50, // 58: essay.v1.ErrorAnalysis.error_distribution:type_name -> essay.v1.ErrorDistributionItem
+	51, // 59: essay.v1.ErrorAnalysis.error_type_ratio:type_name -> essay.v1.ErrorTypeItem
+	52, // 60: essay.v1.ErrorAnalysis.high_frequency_list:type_name -> essay.v1.HighFrequencyError
+	54, // 61: essay.v1.HighlightAnalysis.highlight_distribution:type_name -> essay.v1.HighlightDistributionItem
+	55, // 62: essay.v1.HighlightAnalysis.highlight_type_ratio:type_name -> essay.v1.HighlightTypeItem
+	28, // 63: essay.v1.ScoreEvaluation.EvidenceEntry.value:type_name -> essay.v1.EvidenceList
+	0,  // 64: essay.v1.EssayService.EvaluateStream:input_type -> essay.v1.EvaluateRequest
+	41, // 65: essay.v1.EssayService.TitleOcr:input_type -> essay.v1.TitleOcrRequest
+	44, // 66: essay.v1.EssayService.AnalyzeClassStatistics:input_type -> essay.v1.ClassStatisticsRequest
+	4,  // 67: essay.v1.EssayService.EvaluateStream:output_type -> essay.v1.StreamEvaluateResponse
+	42, // 68: essay.v1.EssayService.TitleOcr:output_type -> essay.v1.TitleOcrResponse
+	45, // 69: essay.v1.EssayService.AnalyzeClassStatistics:output_type -> essay.v1.ClassStatisticsResponse
+	67, // [67:70] is the sub-list for method output_type
+	64, // [64:67] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
+}
+
+func init() { file_essay_proto_init() }
+func file_essay_proto_init() {
+	if File_essay_proto != nil {
+		return
+	}
+	file_essay_proto_msgTypes[0].OneofWrappers = []any{}
+	file_essay_proto_msgTypes[4].OneofWrappers = []any{
+		(*StreamEvaluateResponse_Init)(nil),
+		(*StreamEvaluateResponse_StepData)(nil),
+		(*StreamEvaluateResponse_Result)(nil),
+		(*StreamEvaluateResponse_Error)(nil),
+	}
+	file_essay_proto_msgTypes[5].OneofWrappers = []any{}
+	file_essay_proto_msgTypes[11].OneofWrappers = []any{}
+	file_essay_proto_msgTypes[27].OneofWrappers = []any{}
+	file_essay_proto_msgTypes[34].OneofWrappers = []any{}
+	file_essay_proto_msgTypes[41].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_essay_proto_rawDesc), len(file_essay_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   59,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_essay_proto_goTypes,
+		DependencyIndexes: file_essay_proto_depIdxs,
+		MessageInfos:      file_essay_proto_msgTypes,
+	}.Build()
+	File_essay_proto = out.File
+	file_essay_proto_goTypes = nil
+	file_essay_proto_depIdxs = nil
+}
@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: essay.proto
+
+package essayv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EssayService_EvaluateStream_FullMethodName         = "/essay.v1.EssayService/EvaluateStream"
+	EssayService_TitleOcr_FullMethodName               = "/essay.v1.EssayService/TitleOcr"
+	EssayService_AnalyzeClassStatistics_FullMethodName = "/essay.v1.EssayService/AnalyzeClassStatistics"
+)
+
+// EssayServiceClient is the client API for EssayService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EssayService 作文批改gRPC服务，与HTTP接口共用同一服务层
+type EssayServiceClient interface {
+	// EvaluateStream 流式批改，消息与SSE/WebSocket推送的StreamEvaluateResponse一致
+	EvaluateStream(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEvaluateResponse], error)
+	// TitleOcr 带标题OCR识别
+	TitleOcr(ctx context.Context, in *TitleOcrRequest, opts ...grpc.CallOption) (*TitleOcrResponse, error)
+	// AnalyzeClassStatistics 班级学情统计
+	AnalyzeClassStatistics(ctx context.Context, in *ClassStatisticsRequest, opts ...grpc.CallOption) (*ClassStatisticsResponse, error)
+}
+
+type essayServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEssayServiceClient(cc grpc.ClientConnInterface) EssayServiceClient {
+	return &essayServiceClient{cc}
+}
+
+func (c *essayServiceClient) EvaluateStream(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEvaluateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EssayService_ServiceDesc.Streams[0], EssayService_EvaluateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EvaluateRequest, StreamEvaluateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EssayService_EvaluateStreamClient = grpc.ServerStreamingClient[StreamEvaluateResponse]
+
+func (c *essayServiceClient) TitleOcr(ctx context.Context, in *TitleOcrRequest, opts ...grpc.CallOption) (*TitleOcrResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TitleOcrResponse)
+	err := c.cc.Invoke(ctx, EssayService_TitleOcr_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *essayServiceClient) AnalyzeClassStatistics(ctx context.Context, in *ClassStatisticsRequest, opts ...grpc.CallOption) (*ClassStatisticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClassStatisticsResponse)
+	err := c.cc.Invoke(ctx, EssayService_AnalyzeClassStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EssayServiceServer is the server API for EssayService service.
+// All implementations must embed UnimplementedEssayServiceServer
+// for forward compatibility.
+//
+// EssayService 作文批改gRPC服务，与HTTP接口共用同一服务层
+type EssayServiceServer interface {
+	// EvaluateStream 流式批改，消息与SSE/WebSocket推送的StreamEvaluateResponse一致
+	EvaluateStream(*EvaluateRequest, grpc.ServerStreamingServer[StreamEvaluateResponse]) error
+	// TitleOcr 带标题OCR识别
+	TitleOcr(context.Context, *TitleOcrRequest) (*TitleOcrResponse, error)
+	// AnalyzeClassStatistics 班级学情统计
+	AnalyzeClassStatistics(context.Context, *ClassStatisticsRequest) (*ClassStatisticsResponse, error)
+	mustEmbedUnimplementedEssayServiceServer()
+}
+
+// UnimplementedEssayServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEssayServiceServer struct{}
+
+func (UnimplementedEssayServiceServer) EvaluateStream(*EvaluateRequest, grpc.ServerStreamingServer[StreamEvaluateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method EvaluateStream not implemented")
+}
+func (UnimplementedEssayServiceServer) TitleOcr(context.Context, *TitleOcrRequest) (*TitleOcrResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TitleOcr not implemented")
+}
+func (UnimplementedEssayServiceServer) AnalyzeClassStatistics(context.Context, *ClassStatisticsRequest) (*ClassStatisticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeClassStatistics not implemented")
+}
+func (UnimplementedEssayServiceServer) mustEmbedUnimplementedEssayServiceServer() {}
+func (UnimplementedEssayServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEssayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EssayServiceServer will
+// result in compilation errors.
+type UnsafeEssayServiceServer interface {
+	mustEmbedUnimplementedEssayServiceServer()
+}
+
+func RegisterEssayServiceServer(s grpc.ServiceRegistrar, srv EssayServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEssayServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EssayService_ServiceDesc, srv)
+}
+
+func _EssayService_EvaluateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EvaluateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EssayServiceServer).EvaluateStream(m, &grpc.GenericServerStream[EvaluateRequest, StreamEvaluateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EssayService_EvaluateStreamServer = grpc.ServerStreamingServer[StreamEvaluateResponse]
+
+func _EssayService_TitleOcr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TitleOcrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EssayServiceServer).TitleOcr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EssayService_TitleOcr_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EssayServiceServer).TitleOcr(ctx, req.(*TitleOcrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EssayService_AnalyzeClassStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClassStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EssayServiceServer).AnalyzeClassStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EssayService_AnalyzeClassStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EssayServiceServer).AnalyzeClassStatistics(ctx, req.(*ClassStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EssayService_ServiceDesc is the grpc.ServiceDesc for EssayService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EssayService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "essay.v1.EssayService",
+	HandlerType: (*EssayServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TitleOcr",
+			Handler:    _EssayService_TitleOcr_Handler,
+		},
+		{
+			MethodName: "AnalyzeClassStatistics",
+			Handler:    _EssayService_AnalyzeClassStatistics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EvaluateStream",
+			Handler:       _EssayService_EvaluateStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "essay.proto",
+}
@@ -0,0 +1,4 @@
+// Package essayv1 作文批改gRPC接口定义
+package essayv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative essay.proto
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// 错误注入结果
+const (
+	faultNone = iota // 正常返回
+	faultHTTP        // 返回HTTP错误状态码
+	faultCode        // HTTP 200，业务code失败
+)
+
+// FaultConfig 延迟与错误注入配置
+type FaultConfig struct {
+	Latency       time.Duration
+	Jitter        time.Duration
+	StepLatency   map[string]time.Duration
+	ErrorRate     float64
+	ErrorStatus   int
+	CodeErrorRate float64
+	AlwaysFail    []string
+	Seed          int64
+}
+
+// FaultInjector 延迟与错误注入器，使用固定种子保证序列可复现
+type FaultInjector struct {
+	config     FaultConfig
+	alwaysFail map[string]bool
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewFaultInjector 创建错误注入器
+func NewFaultInjector(config FaultConfig) *FaultInjector {
+	alwaysFail := make(map[string]bool, len(config.AlwaysFail))
+	for _, name := range config.AlwaysFail {
+		alwaysFail[name] = true
+	}
+	if config.ErrorStatus == 0 {
+		config.ErrorStatus = 500
+	}
+
+	return &FaultInjector{
+		config:     config,
+		alwaysFail: alwaysFail,
+		rnd:        rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// Delay 按配置等待，客户端断开时提前返回false
+func (f *FaultInjector) Delay(ctx context.Context, name string) bool {
+	d := f.config.Latency
+	if override, ok := f.config.StepLatency[name]; ok {
+		d = override
+	}
+	if f.config.Jitter > 0 {
+		d += time.Duration(f.float64() * float64(f.config.Jitter))
+	}
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Decide 决定本次请求是否注入错误
+func (f *FaultInjector) Decide(name string) int {
+	if f.alwaysFail[name] {
+		return faultHTTP
+	}
+
+	r := f.float64()
+	switch {
+	case r < f.config.ErrorRate:
+		return faultHTTP
+	case r < f.config.ErrorRate+f.config.CodeErrorRate:
+		return faultCode
+	}
+	return faultNone
+}
+
+// ErrorStatus 注入HTTP错误时的状态码
+func (f *FaultInjector) ErrorStatus() int {
+	return f.config.ErrorStatus
+}
+
+func (f *FaultInjector) float64() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rnd.Float64()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+//go:embed fixtures/*.json
+var embeddedFixtures embed.FS
+
+// fixture文件名（不含扩展名）
+var fixtureNames = []string{
+	"essay_info", "word_sentence", "grammar_info", "overall", "suggestion",
+	"paragraph", "score", "polishing", "bee_ocr", "chat",
+}
+
+// Fixtures 各接口的响应数据
+type Fixtures struct {
+	raw       map[string][]byte
+	Polishing []map[string]any
+	Chat      ChatFixture
+}
+
+// ChatFixture chat/completions 响应内容
+type ChatFixture struct {
+	Model     string `json:"model"`
+	WithTitle string `json:"with_title"` // 提示词要求返回标题时的回复
+	TextOnly  string `json:"text_only"`  // 其余情况的回复
+}
+
+// LoadFixtures 加载内置fixtures，dir不为空时用其中的同名文件覆盖
+func LoadFixtures(dir string) (*Fixtures, error) {
+	f := &Fixtures{raw: make(map[string][]byte, len(fixtureNames))}
+
+	for _, name := range fixtureNames {
+		file := name + ".json"
+		data, err := embeddedFixtures.ReadFile("fixtures/" + file)
+		if err != nil {
+			return nil, err
+		}
+
+		if dir != "" {
+			override, err := os.ReadFile(filepath.Join(dir, file))
+			switch {
+			case err == nil:
+				data = override
+			case !errors.Is(err, fs.ErrNotExist):
+				return nil, err
+			}
+		}
+
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("fixture %s 不是合法的JSON", file)
+		}
+		f.raw[name] = data
+	}
+
+	if err := json.Unmarshal(f.raw["polishing"], &f.Polishing); err != nil {
+		return nil, fmt.Errorf("解析polishing fixture失败: %w", err)
+	}
+	if err := json.Unmarshal(f.raw["chat"], &f.Chat); err != nil {
+		return nil, fmt.Errorf("解析chat fixture失败: %w", err)
+	}
+	return f, nil
+}
+
+// Raw 原样返回的fixture
+func (f *Fixtures) Raw(name string) []byte {
+	return f.raw[name]
+}
@@ -0,0 +1,94 @@
+// mock-upstream 本地模拟上游服务
+//
+// 实现作文批改各上游接口（essay_info、word_sentence、grammar_info、overall、suggestion、
+// paragraph、score、流式polishing）、Bee OCR 以及 OpenAI 兼容的 chat/completions 接口，
+// 响应来自fixtures目录，可配置延迟与错误注入，便于离线开发与联调。
+//
+//	go run ./cmd/mock-upstream -addr :8091 -latency 200ms -jitter 300ms -error-rate 0.1
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	var (
+		addr          = flag.String("addr", ":8091", "监听地址")
+		fixturesDir   = flag.String("fixtures", "", "fixtures目录，为空时使用内置fixtures；目录中同名文件覆盖内置文件")
+		latency       = flag.Duration("latency", 0, "所有接口的基础延迟")
+		jitter        = flag.Duration("jitter", 0, "在基础延迟上叠加的随机延迟上限")
+		stepLatency   = flag.String("step-latency", "", "按接口覆盖基础延迟，如 score=3s,polishing=500ms")
+		chunkDelay    = flag.Duration("chunk-delay", 300*time.Millisecond, "流式润色每个段落之间的间隔")
+		errorRate     = flag.Float64("error-rate", 0, "返回HTTP错误的概率（0~1）")
+		errorStatus   = flag.Int("error-status", 500, "注入HTTP错误时的状态码")
+		codeErrorRate = flag.Float64("code-error-rate", 0, "返回HTTP 200但业务code失败的概率（0~1），流式润色表现为中途返回error事件")
+		fail          = flag.String("fail", "", "总是失败的接口，逗号分隔，如 essay_info,score")
+		seed          = flag.Int64("seed", 1, "随机种子，相同种子下延迟与错误注入序列可复现")
+	)
+	flag.Parse()
+
+	fixtures, err := LoadFixtures(*fixturesDir)
+	if err != nil {
+		log.Fatal("Failed to load fixtures:", err)
+	}
+
+	overrides, err := parseStepLatency(*stepLatency)
+	if err != nil {
+		log.Fatal("Invalid -step-latency:", err)
+	}
+
+	faults := NewFaultInjector(FaultConfig{
+		Latency:       *latency,
+		Jitter:        *jitter,
+		StepLatency:   overrides,
+		ErrorRate:     *errorRate,
+		ErrorStatus:   *errorStatus,
+		CodeErrorRate: *codeErrorRate,
+		AlwaysFail:    splitList(*fail),
+		Seed:          *seed,
+	})
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Logger(), gin.Recovery())
+	NewServer(fixtures, faults, *chunkDelay).Register(router)
+
+	logrus.Infof("Mock upstream listening on %s", *addr)
+	if err := router.Run(*addr); err != nil {
+		log.Fatal("Failed to start mock upstream:", err)
+	}
+}
+
+// parseStepLatency 解析 name=duration 列表
+func parseStepLatency(value string) (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration)
+	for _, item := range splitList(value) {
+		name, raw, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("期望 name=duration 格式: %s", item)
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimSpace(name)] = d
+	}
+	return result, nil
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
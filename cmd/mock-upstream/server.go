@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const injectedErrorMessage = "mock injected error"
+
+// evaluateEndpoints 批改上游接口，值表示业务code是否为数字类型
+var evaluateEndpoints = map[string]bool{
+	"essay_info":    false,
+	"word_sentence": true,
+	"grammar_info":  false,
+	"overall":       false,
+	"suggestion":    false,
+	"paragraph":     false,
+	"score":         false,
+}
+
+// Server 模拟上游服务
+type Server struct {
+	fixtures   *Fixtures
+	faults     *FaultInjector
+	chunkDelay time.Duration
+}
+
+// NewServer 创建模拟上游服务
+func NewServer(fixtures *Fixtures, faults *FaultInjector, chunkDelay time.Duration) *Server {
+	return &Server{
+		fixtures:   fixtures,
+		faults:     faults,
+		chunkDelay: chunkDelay,
+	}
+}
+
+// Register 注册路由
+func (s *Server) Register(router *gin.Engine) {
+	router.GET("/healthz", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	for name, numericCode := range evaluateEndpoints {
+		router.POST("/"+name, s.evaluate(name, numericCode))
+	}
+	router.POST("/polishing", s.polishing)
+	router.POST("/bee/ocr", s.beeOCR)
+	router.POST("/v1/chat/completions", s.chatCompletions)
+	router.POST("/chat/completions", s.chatCompletions)
+}
+
+// evaluate 普通批改接口：返回对应fixture
+func (s *Server) evaluate(name string, numericCode bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body map[string]any
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "400", "message": err.Error()})
+			return
+		}
+		if !s.faults.Delay(c.Request.Context(), name) {
+			return
+		}
+
+		switch s.faults.Decide(name) {
+		case faultHTTP:
+			c.JSON(s.faults.ErrorStatus(), gin.H{"code": fmt.Sprint(s.faults.ErrorStatus()), "message": injectedErrorMessage})
+			return
+		case faultCode:
+			var code any = "500"
+			if numericCode {
+				code = 500
+			}
+			c.JSON(http.StatusOK, gin.H{"code": code, "message": injectedErrorMessage})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json; charset=utf-8", s.fixtures.Raw(name))
+	}
+}
+
+// polishing 流式润色：每个段落一条 type=content 的SSE事件，最后发送 type=end
+func (s *Server) polishing(c *gin.Context) {
+	var body map[string]any
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "400", "message": err.Error()})
+		return
+	}
+	if !s.faults.Delay(c.Request.Context(), "polishing") {
+		return
+	}
+
+	fault := s.faults.Decide("polishing")
+	if fault == faultHTTP {
+		c.JSON(s.faults.ErrorStatus(), gin.H{"code": fmt.Sprint(s.faults.ErrorStatus()), "message": injectedErrorMessage})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+
+	for i, chunk := range s.fixtures.Polishing {
+		if i > 0 && !s.sleep(c, s.chunkDelay) {
+			return
+		}
+
+		event := make(map[string]any, len(chunk)+1)
+		for k, v := range chunk {
+			event[k] = v
+		}
+		event["type"] = "content"
+		s.writeEvent(c, event)
+
+		// 业务错误在第一个段落之后返回，模拟上游中途失败
+		if fault == faultCode {
+			s.writeEvent(c, map[string]any{"type": "error", "message": injectedErrorMessage})
+			return
+		}
+	}
+
+	s.writeEvent(c, map[string]any{"type": "end"})
+}
+
+func (s *Server) writeEvent(c *gin.Context, event any) {
+	data, _ := json.Marshal(event)
+	fmt.Fprintf(c.Writer, "data:%s\n\n", data)
+	c.Writer.Flush()
+}
+
+func (s *Server) sleep(c *gin.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
+
+// beeOCR Bee OCR：需携带 x-app-key 请求头，请求体包含 image_url 或 image_base64
+func (s *Server) beeOCR(c *gin.Context) {
+	var body map[string]any
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "msg": err.Error()})
+		return
+	}
+	if !s.faults.Delay(c.Request.Context(), "bee_ocr") {
+		return
+	}
+
+	if c.GetHeader("x-app-key") == "" {
+		c.JSON(http.StatusOK, gin.H{"code": 401, "msg": "missing x-app-key"})
+		return
+	}
+	hasImage := false
+	for key := range body {
+		if strings.HasPrefix(key, "image_") {
+			hasImage = true
+		}
+	}
+	if !hasImage {
+		c.JSON(http.StatusOK, gin.H{"code": 400, "msg": "missing image"})
+		return
+	}
+
+	switch s.faults.Decide("bee_ocr") {
+	case faultHTTP:
+		c.JSON(s.faults.ErrorStatus(), gin.H{"code": s.faults.ErrorStatus(), "msg": injectedErrorMessage})
+		return
+	case faultCode:
+		c.JSON(http.StatusOK, gin.H{"code": 500, "msg": injectedErrorMessage})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", s.fixtures.Raw("bee_ocr"))
+}
+
+// chatCompletions OpenAI兼容的对话接口，支持 stream=true
+func (s *Server) chatCompletions(c *gin.Context) {
+	var req struct {
+		Model    string            `json:"model"`
+		Messages []json.RawMessage `json:"messages"`
+		Stream   bool              `json:"stream"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, openAIError(err.Error()))
+		return
+	}
+	if !s.faults.Delay(c.Request.Context(), "chat") {
+		return
+	}
+
+	switch s.faults.Decide("chat") {
+	case faultHTTP:
+		c.JSON(s.faults.ErrorStatus(), openAIError(injectedErrorMessage))
+		return
+	case faultCode:
+		// OpenAI协议没有业务code，以空choices模拟异常响应
+		c.JSON(http.StatusOK, openai.ChatCompletionResponse{ID: "mock-empty", Object: "chat.completion", Model: req.Model})
+		return
+	}
+
+	// 提示词要求提取作文标题时返回JSON格式的标题和正文
+	content := s.fixtures.Chat.TextOnly
+	for _, msg := range req.Messages {
+		if strings.Contains(string(msg), "作文标题") {
+			content = s.fixtures.Chat.WithTitle
+		}
+	}
+
+	model := req.Model
+	if model == "" {
+		model = s.fixtures.Chat.Model
+	}
+	created := time.Now().Unix()
+
+	if req.Stream {
+		s.streamChat(c, model, created, content)
+		return
+	}
+
+	c.JSON(http.StatusOK, openai.ChatCompletionResponse{
+		ID:      "mock-chat",
+		Object:  "chat.completion",
+		Created: created,
+		Model:   model,
+		Choices: []openai.ChatCompletionChoice{{
+			Index:        0,
+			Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content},
+			FinishReason: openai.FinishReasonStop,
+		}},
+		Usage: openai.Usage{
+			PromptTokens:     len(req.Messages),
+			CompletionTokens: utf8.RuneCountInString(content),
+			TotalTokens:      len(req.Messages) + utf8.RuneCountInString(content),
+		},
+	})
+}
+
+// streamChat 按固定长度切分回复内容，以chat.completion.chunk事件推送
+func (s *Server) streamChat(c *gin.Context, model string, created int64, content string) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Status(http.StatusOK)
+
+	const chunkSize = 16
+	runes := []rune(content)
+	for start := 0; start < len(runes); start += chunkSize {
+		end := min(start+chunkSize, len(runes))
+		chunk := openai.ChatCompletionStreamResponse{
+			ID:      "mock-chat",
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []openai.ChatCompletionStreamChoice{{
+				Index: 0,
+				Delta: openai.ChatCompletionStreamChoiceDelta{Content: string(runes[start:end])},
+			}},
+		}
+		if end == len(runes) {
+			chunk.Choices[0].FinishReason = openai.FinishReasonStop
+		}
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+		c.Writer.Flush()
+	}
+	fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+	c.Writer.Flush()
+}
+
+func openAIError(message string) gin.H {
+	return gin.H{"error": gin.H{"message": message, "type": "mock_error"}}
+}
@@ -0,0 +1,168 @@
+// replay-logs 原始日志回放与差异比较工具
+//
+// 从sts_logs中按URL前缀和时间范围拉取原始请求，使用当前配置（CONFIG_PATH）重放批改、OCR或学情统计，
+// 与日志中的原响应比较分数、各类标注数量和评语相似度。切换 model_version 前应先跑一遍：
+//
+//	CONFIG_PATH=config.yaml go run ./cmd/replay-logs -url /evaluate/stream -since 2025-01-01 -until 2025-02-01 -limit 200
+//
+// 每条日志的差异以JSON Lines写入 -out，汇总输出到标准输出；设置 -max-score-delta 时，
+// 任一分数的平均绝对差超过阈值则以退出码1结束，可用于发布前检查。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/domain/replay"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+	"essay-stateless/pkg/database"
+	"essay-stateless/pkg/httpclient"
+	"essay-stateless/pkg/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	var (
+		url           = flag.String("url", "", "日志URL前缀，如 /evaluate/stream、/evaluate/ocr/stream、/sts/ocr/title/、grpc:/essay.v1.EssayService/TitleOcr、/statistics/class（必填）")
+		since         = flag.String("since", "", "起始时间（含），格式 2006-01-02 或 RFC3339")
+		until         = flag.String("until", "", "结束时间（不含），格式 2006-01-02 或 RFC3339")
+		limit         = flag.Int64("limit", 100, "最多回放条数，0不限制")
+		concurrency   = flag.Int("concurrency", 4, "并发回放数")
+		timeout       = flag.Duration("timeout", 5*time.Minute, "单条回放超时")
+		out           = flag.String("out", "replay-diffs.jsonl", "逐条差异输出文件（JSON Lines），- 表示标准错误")
+		maxScoreDelta = flag.Float64("max-score-delta", 0, "任一分数平均绝对差超过该值时退出码为1，0不检查")
+	)
+	flag.Parse()
+
+	if *url == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	start, err := parseTime(*since)
+	if err != nil {
+		log.Fatal("Invalid -since:", err)
+	}
+	end, err := parseTime(*until)
+	if err != nil {
+		log.Fatal("Invalid -until:", err)
+	}
+
+	cfg := config.Load()
+	logger.Init(cfg.Log)
+
+	if err := httpclient.UseCassettes(cfg.Cassette.Mode, cfg.Cassette.Dir); err != nil {
+		log.Fatal("Failed to initialize http cassettes:", err)
+	}
+
+	db, err := database.NewMongoDB(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Disconnect()
+
+	// 回放不读写步骤耗时统计，避免回放流量改变线上的进度预估
+	replayService := appService.NewReplayServiceV2(
+		repository.NewRawLogsRepository(db.Database()),
+		appService.NewEvaluateServiceV2(&cfg.Evaluate, nil, repository.NewEvaluationRepository(db.Database()), nil, nil),
+		appService.NewOcrServiceV2(&cfg.OCR),
+		appService.NewStatisticsServiceV2(),
+	)
+
+	ctx := context.Background()
+	logs, err := replayService.FindLogs(ctx, repository.RawLogsFilter{
+		URLPrefix: *url,
+		Start:     start,
+		End:       end,
+		Limit:     *limit,
+	})
+	if err != nil {
+		log.Fatal("Failed to query raw logs:", err)
+	}
+	logrus.Infof("共 %d 条日志待回放", len(logs))
+
+	writer := os.Stderr
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal("Failed to create output file:", err)
+		}
+		defer f.Close()
+		writer = f
+	}
+
+	summary := replayAll(ctx, replayService, logs, max(*concurrency, 1), *timeout, json.NewEncoder(writer))
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(summary); err != nil {
+		log.Fatal("Failed to write summary:", err)
+	}
+
+	if *maxScoreDelta > 0 {
+		if changed := summary.ChangedScores(*maxScoreDelta); len(changed) > 0 {
+			fmt.Fprintf(os.Stderr, "分数平均差超过 %.2f: %v\n", *maxScoreDelta, changed)
+			os.Exit(1)
+		}
+	}
+}
+
+// replayAll 并发回放，按日志顺序写出差异
+func replayAll(
+	ctx context.Context,
+	replayService *appService.ReplayServiceV2,
+	logs []*model.RawLogs,
+	concurrency int,
+	timeout time.Duration,
+	encoder *json.Encoder,
+) *replay.Summary {
+	diffs := make([]*replay.Diff, len(logs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, rawLog := range logs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			replayCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			diffs[i] = replayService.Replay(replayCtx, rawLog)
+			if diffs[i].Error != "" {
+				logrus.Warnf("回放失败 [%s %s]: %s", rawLog.ID.Hex(), rawLog.URL, diffs[i].Error)
+			}
+		}()
+	}
+	wg.Wait()
+
+	summary := replay.NewSummary()
+	for _, diff := range diffs {
+		summary.Add(diff)
+		if err := encoder.Encode(diff); err != nil {
+			logrus.WithError(err).Error("Failed to write diff")
+		}
+	}
+	return summary
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditServiceV2 批改审计查询服务
+type AuditServiceV2 struct {
+	auditRepo      repository.EvaluationAuditRepository
+	evaluationRepo repository.EvaluationRepository
+}
+
+// NewAuditServiceV2 创建审计查询服务
+func NewAuditServiceV2(auditRepo repository.EvaluationAuditRepository, evaluationRepo repository.EvaluationRepository) *AuditServiceV2 {
+	return &AuditServiceV2{
+		auditRepo:      auditRepo,
+		evaluationRepo: evaluationRepo,
+	}
+}
+
+// GetBundle 按trace ID查询审计包：各步骤上游原始请求/响应及对应的最终批改结果
+func (s *AuditServiceV2) GetBundle(ctx context.Context, traceID string) (*model.AuditBundle, error) {
+	audits, err := s.auditRepo.FindByTraceID(ctx, traceID)
+	if err != nil {
+		return nil, fmt.Errorf("查询审计记录失败: %w", err)
+	}
+	if len(audits) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	bundle := &model.AuditBundle{TraceID: traceID}
+	for _, audit := range audits {
+		item := model.AuditBundleItem{Audit: audit}
+		if objectID, err := primitive.ObjectIDFromHex(audit.EvaluationID); err == nil {
+			if evaluation, err := s.evaluationRepo.FindByID(ctx, objectID); err == nil {
+				item.Evaluation = evaluation
+			} else {
+				logrus.Warnf("审计记录对应的批改结果查询失败 [%s]: %v", audit.EvaluationID, err)
+			}
+		}
+		bundle.Items = append(bundle.Items, item)
+	}
+	return bundle, nil
+}
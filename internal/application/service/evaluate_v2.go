@@ -4,33 +4,74 @@ import (
 	"context"
 	"essay-stateless/internal/config"
 	"essay-stateless/internal/domain/evaluate"
+	"essay-stateless/internal/domain/genre"
+	"essay-stateless/internal/domain/rubric"
 	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // EvaluateServiceV2 新版评估服务（基于DDD架构）
 type EvaluateServiceV2 struct {
-	config *config.EvaluateConfig
+	config         *config.EvaluateConfig
+	evaluationRepo repository.EvaluationRepository
+	auditRepo      repository.EvaluationAuditRepository
 
 	// 领域对象
 	contentCleaner    *evaluate.ContentCleaner
 	clientsFactory    *evaluate.APIClientsFactory
 	streamCoordinator *evaluate.StreamCoordinator
 	responseProcessor *evaluate.ResponseProcessor
+	rubricRegistry    *rubric.Registry
 }
 
-// NewEvaluateServiceV2 创建新版评估服务
-func NewEvaluateServiceV2(config *config.EvaluateConfig) *EvaluateServiceV2 {
+// NewEvaluateServiceV2 创建新版评估服务，shadowStore为nil时不启用影子流量，auditRepo为nil时不归档上游原始记录
+func NewEvaluateServiceV2(
+	config *config.EvaluateConfig,
+	latencyStore evaluate.LatencyStore,
+	evaluationRepo repository.EvaluationRepository,
+	shadowStore evaluate.ShadowStore,
+	auditRepo repository.EvaluationAuditRepository,
+) *EvaluateServiceV2 {
+	latencyTracker := evaluate.NewLatencyTracker(latencyStore)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := latencyTracker.Load(ctx); err != nil {
+		logrus.Warnf("加载步骤耗时统计失败，使用默认预估: %v", err)
+	}
+
 	return &EvaluateServiceV2{
-		config:            config,
-		contentCleaner:    evaluate.NewContentCleaner(),
-		clientsFactory:    evaluate.NewAPIClientsFactory(&config.API),
-		streamCoordinator: evaluate.NewStreamCoordinator(),
+		config:         config,
+		evaluationRepo: evaluationRepo,
+		auditRepo:      auditRepo,
+		contentCleaner: evaluate.NewContentCleaner(),
+		clientsFactory: evaluate.NewAPIClientsFactory(&config.API),
+		streamCoordinator: evaluate.NewStreamCoordinator(
+			latencyTracker,
+			evaluate.NewShadowRunner(config.Shadow, shadowStore),
+			rubric.NewBandClassifier(config.ExamBand),
+			rubric.NewScoreNormalizer(config.ScoreNormalization),
+			rubric.NewOffTopicGate(config.OffTopic),
+			evaluate.NewEnsembleScorer(config.ScoreEnsemble, config.API.Score),
+			genre.NewProfiles(config.GenreProfiles, evaluate.ParallelSteps()),
+		),
 		responseProcessor: evaluate.NewResponseProcessor(),
+		rubricRegistry:    rubric.NewRegistry(config.Rubrics),
 	}
 }
 
+// PrepareRequest 校验并补全请求：解析评分量表（rubricId引用或请求内提供），核对并补全各维度总分
+//
+// 返回的错误均为请求参数错误，须在开始推送前调用
+func (s *EvaluateServiceV2) PrepareRequest(req *model.EvaluateRequest) error {
+	return s.rubricRegistry.Resolve(req)
+}
+
 // EvaluateStream 流式批改评估
 //
 // DDD架构实现，包含：
@@ -41,9 +82,14 @@ func NewEvaluateServiceV2(config *config.EvaluateConfig) *EvaluateServiceV2 {
 // - 重试执行器（RetryExecutor）
 // - 分数计算器（ScoreCalculator）
 // - 位置计算器（PositionCalculator）
-func (s *EvaluateServiceV2) EvaluateStream(ctx context.Context, req *model.EvaluateRequest, ch chan<- *model.StreamEvaluateResponse) error {
+//
+// 批改完成后结果会持久化，保存成功时complete消息中携带evaluationId，可通过 GetEvaluation 查询；
+// 客户端取消或断开时不发送complete消息，也不保存
+func (s *EvaluateServiceV2) EvaluateStream(ctx context.Context, req *model.EvaluateRequest, userID string, ch chan<- *model.StreamEvaluateResponse) error {
 	logrus.Info("EvaluateServiceV2: 开始流式作文批改")
 
+	createTime := time.Now()
+
 	// 1. 清理内容（使用领域对象）
 	req.Content = s.contentCleaner.Clean(req.Content)
 	logrus.Infof("清理后作文：%s", req.Content)
@@ -54,8 +100,46 @@ func (s *EvaluateServiceV2) EvaluateStream(ctx context.Context, req *model.Evalu
 		Version: s.config.ModelVersion.Version,
 	}
 
-	// 3. 使用流式协调器进行评估（ResponseProcessor在内部被调用）
-	err := s.streamCoordinator.CoordinateEvaluation(ctx, req, ch, s.clientsFactory, modelVersion)
+	// 3. 记录各步骤上游原始请求与响应，批改结束后按trace ID归档
+	var audit *evaluate.AuditRecorder
+	if s.auditRepo != nil && s.config.Audit.Enabled {
+		audit = evaluate.NewAuditRecorder()
+		ctx = audit.Attach(ctx)
+	}
+
+	// 4. 使用流式协调器进行评估（ResponseProcessor在内部被调用），转发消息并在完成时持久化
+	//
+	// complete消息在保存之后推送，保存成功才携带evaluationId，客户端拿到的ID一定可查；已取消的批改不保存
+	coordinatorChan := make(chan *model.StreamEvaluateResponse, cap(ch))
+	forwardDone := make(chan struct{})
+	var evaluationID string
+	go func() {
+		defer close(forwardDone)
+		defer close(ch)
+		for msg := range coordinatorChan {
+			result, ok := msg.Data.(*model.EvaluateResponse)
+			if msg.Type != "complete" || !ok {
+				ch <- msg
+				continue
+			}
+			if ctx.Err() != nil {
+				logrus.Warn("批改已取消，丢弃完成消息")
+				continue
+			}
+			evaluation := s.newEvaluation(ctx, req, userID, result, createTime)
+			if s.saveEvaluation(ctx, evaluation) {
+				evaluationID = evaluation.ID.Hex()
+				msg.EvaluationID = evaluationID
+			}
+			ch <- msg
+		}
+	}()
+
+	err := s.streamCoordinator.CoordinateEvaluation(ctx, req, coordinatorChan, s.clientsFactory, modelVersion)
+	<-forwardDone
+	if audit != nil {
+		s.saveAudit(ctx, audit, req, userID, evaluationID, createTime)
+	}
 	if err != nil {
 		logrus.Errorf("评估协调失败: %v", err)
 		return err
@@ -64,3 +148,109 @@ func (s *EvaluateServiceV2) EvaluateStream(ctx context.Context, req *model.Evalu
 	logrus.Info("EvaluateServiceV2: 流式作文批改完成")
 	return nil
 }
+
+// Evaluate 非流式批改，返回最终结果，不持久化（用于日志回放等离线场景）
+func (s *EvaluateServiceV2) Evaluate(ctx context.Context, req *model.EvaluateRequest) (*model.EvaluateResponse, error) {
+	if err := s.PrepareRequest(req); err != nil {
+		return nil, err
+	}
+	req.Content = s.contentCleaner.Clean(req.Content)
+
+	modelVersion := model.ModelVersion{
+		Name:    s.config.ModelVersion.Name,
+		Version: s.config.ModelVersion.Version,
+	}
+
+	ch := make(chan *model.StreamEvaluateResponse, 50)
+	var result *model.EvaluateResponse
+	var stepErr string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range ch {
+			switch msg.Type {
+			case "complete":
+				result, _ = msg.Data.(*model.EvaluateResponse)
+			case "error":
+				stepErr = msg.Message
+				if data, ok := msg.Data.(*model.StreamErrorData); ok {
+					stepErr += ": " + data.Error
+				}
+			}
+		}
+	}()
+
+	err := s.streamCoordinator.CoordinateEvaluation(ctx, req, ch, s.clientsFactory, modelVersion)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("批改未完成: %s", stepErr)
+	}
+	return result, nil
+}
+
+// GetEvaluation 根据ID查询已完成的批改记录，只能查询该用户自己的记录，其他用户的记录视为不存在
+func (s *EvaluateServiceV2) GetEvaluation(ctx context.Context, id, userID string) (*model.Evaluation, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, repository.ErrNotFound
+	}
+	evaluation, err := s.evaluationRepo.FindByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	return evaluation, nil
+}
+
+// newEvaluation 构建待持久化的批改记录
+func (s *EvaluateServiceV2) newEvaluation(ctx context.Context, req *model.EvaluateRequest, userID string, result *model.EvaluateResponse, createTime time.Time) *model.Evaluation {
+	evaluation := &model.Evaluation{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		Request:    req,
+		Result:     result,
+		CreateTime: createTime,
+		UpdateTime: time.Now(),
+	}
+	if spanContext := trace.SpanFromContext(ctx).SpanContext(); spanContext.HasTraceID() {
+		evaluation.TraceID = spanContext.TraceID().String()
+	}
+	return evaluation
+}
+
+// saveEvaluation 持久化批改结果，不随请求取消：保存期间客户端断开也不丢失记录
+func (s *EvaluateServiceV2) saveEvaluation(ctx context.Context, evaluation *model.Evaluation) bool {
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.evaluationRepo.Save(saveCtx, evaluation); err != nil {
+		logrus.Errorf("保存批改结果失败: %v", err)
+		return false
+	}
+	return true
+}
+
+// saveAudit 归档本次批改的上游原始记录，批改失败时同样保存以便排查
+func (s *EvaluateServiceV2) saveAudit(ctx context.Context, audit *evaluate.AuditRecorder, req *model.EvaluateRequest, userID, evaluationID string, createTime time.Time) {
+	record := &model.EvaluationAudit{
+		ID:           primitive.NewObjectID(),
+		EvaluationID: evaluationID,
+		UserID:       userID,
+		Request:      evaluate.AuditRequest(req),
+		Exchanges:    audit.Exchanges(),
+		CreateTime:   createTime,
+	}
+	if spanContext := trace.SpanFromContext(ctx).SpanContext(); spanContext.HasTraceID() {
+		record.TraceID = spanContext.TraceID().String()
+	}
+
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.auditRepo.Save(saveCtx, record); err != nil {
+		logrus.Errorf("保存审计记录失败: %v", err)
+	}
+}
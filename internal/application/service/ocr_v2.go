@@ -43,6 +43,35 @@ func (s *OcrServiceV2) TitleOcr(ctx context.Context, provider, imageType string,
 	}
 }
 
+// EvaluateRequest 识别手写原稿并生成批改请求，原稿图片一并带上用于卷面评分
+func (s *OcrServiceV2) EvaluateRequest(ctx context.Context, req *model.OcrEvaluateRequest) (*model.EvaluateRequest, error) {
+	provider, imageType := "", "url"
+	if req.Provider != nil {
+		provider = *req.Provider
+	}
+	if req.ImageType != nil {
+		imageType = *req.ImageType
+	}
+	titleReq := &model.TitleOcrRequest{Images: req.Images}
+	if req.LeftType != "" {
+		titleReq.LeftType = &req.LeftType
+	}
+
+	ocrResult, err := s.TitleOcr(ctx, provider, imageType, titleReq)
+	if err != nil {
+		return nil, err
+	}
+	return &model.EvaluateRequest{
+		Title:           ocrResult.Title,
+		Content:         ocrResult.Content,
+		Grade:           req.Grade,
+		EssayType:       req.EssayType,
+		Images:          req.Images,
+		AppearanceScore: req.AppearanceScore,
+		ReferenceEssay:  req.ReferenceEssay,
+	}, nil
+}
+
 // beeTitleOcr 使用Bee提供商进行OCR识别
 func (s *OcrServiceV2) beeTitleOcr(ctx context.Context, imageType string, req *model.TitleOcrRequest) (*model.TitleOcrResponse, error) {
 	leftType := "all"
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"essay-stateless/internal/domain/replay"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+	"fmt"
+	"strings"
+)
+
+// 可回放的日志URL
+const (
+	evaluateStreamURL     = "/evaluate/stream"
+	evaluateWSURL         = "/evaluate/ws"
+	ocrEvaluateStreamURL  = "/evaluate/ocr/stream"
+	grpcEvaluateStreamURL = "grpc:/essay.v1.EssayService/EvaluateStream"
+	titleOcrURLPrefix     = "/sts/ocr/title/"
+	grpcTitleOcrURL       = "grpc:/essay.v1.EssayService/TitleOcr"
+	statisticsURL         = "/statistics/class"
+	grpcStatisticsURL     = "grpc:/essay.v1.EssayService/AnalyzeClassStatistics"
+)
+
+// ReplayServiceV2 原始日志回放服务：用当前配置重放sts_logs中的请求并与原响应比较
+type ReplayServiceV2 struct {
+	rawLogsRepo       repository.RawLogsRepository
+	evaluateService   *EvaluateServiceV2
+	ocrService        *OcrServiceV2
+	statisticsService *StatisticsServiceV2
+	differ            *replay.Differ
+}
+
+// NewReplayServiceV2 创建日志回放服务
+func NewReplayServiceV2(
+	rawLogsRepo repository.RawLogsRepository,
+	evaluateService *EvaluateServiceV2,
+	ocrService *OcrServiceV2,
+	statisticsService *StatisticsServiceV2,
+) *ReplayServiceV2 {
+	return &ReplayServiceV2{
+		rawLogsRepo:       rawLogsRepo,
+		evaluateService:   evaluateService,
+		ocrService:        ocrService,
+		statisticsService: statisticsService,
+		differ:            replay.NewDiffer(),
+	}
+}
+
+// FindLogs 按URL前缀和时间范围查询原始日志
+func (s *ReplayServiceV2) FindLogs(ctx context.Context, filter repository.RawLogsFilter) ([]*model.RawLogs, error) {
+	return s.rawLogsRepo.Find(ctx, filter)
+}
+
+// Replay 重放一条日志，失败信息记录在Diff.Error中
+func (s *ReplayServiceV2) Replay(ctx context.Context, log *model.RawLogs) *replay.Diff {
+	diff, err := s.replay(ctx, log)
+	if diff == nil {
+		diff = &replay.Diff{}
+	}
+	if err != nil {
+		diff.Error = err.Error()
+	}
+	diff.LogID = log.ID.Hex()
+	diff.URL = log.URL
+	diff.CreateTime = log.CreateTime
+	return diff
+}
+
+func (s *ReplayServiceV2) replay(ctx context.Context, log *model.RawLogs) (*replay.Diff, error) {
+	switch {
+	case log.URL == evaluateStreamURL || log.URL == evaluateWSURL || log.URL == grpcEvaluateStreamURL:
+		return s.replayEvaluate(ctx, log)
+	case log.URL == ocrEvaluateStreamURL:
+		return s.replayOCREvaluate(ctx, log)
+	case strings.HasPrefix(log.URL, titleOcrURLPrefix), strings.HasPrefix(log.URL, grpcTitleOcrURL):
+		return s.replayOCR(ctx, log)
+	case log.URL == statisticsURL || log.URL == grpcStatisticsURL:
+		return s.replayStatistics(ctx, log)
+	}
+	return &replay.Diff{}, fmt.Errorf("不支持回放的URL: %s", log.URL)
+}
+
+func (s *ReplayServiceV2) replayEvaluate(ctx context.Context, log *model.RawLogs) (*replay.Diff, error) {
+	diff := &replay.Diff{Kind: replay.KindEvaluate}
+
+	var req model.EvaluateRequest
+	if err := json.Unmarshal([]byte(log.Request), &req); err != nil {
+		return diff, fmt.Errorf("解析请求失败: %w", err)
+	}
+	return s.diffEvaluate(ctx, diff, log, &req)
+}
+
+// replayOCREvaluate 日志中是原始OCR请求，重新识别原稿后再批改，识别结果的变化同样反映在差异中
+func (s *ReplayServiceV2) replayOCREvaluate(ctx context.Context, log *model.RawLogs) (*replay.Diff, error) {
+	diff := &replay.Diff{Kind: replay.KindEvaluate}
+
+	var ocrReq model.OcrEvaluateRequest
+	if err := json.Unmarshal([]byte(log.Request), &ocrReq); err != nil {
+		return diff, fmt.Errorf("解析请求失败: %w", err)
+	}
+	if len(ocrReq.Images) == 0 {
+		return diff, fmt.Errorf("请求中没有原稿图片")
+	}
+
+	req, err := s.ocrService.EvaluateRequest(ctx, &ocrReq)
+	if err != nil {
+		return diff, fmt.Errorf("回放OCR失败: %w", err)
+	}
+	if err := s.evaluateService.PrepareRequest(req); err != nil {
+		return diff, fmt.Errorf("回放批改失败: %w", err)
+	}
+	return s.diffEvaluate(ctx, diff, log, req)
+}
+
+// diffEvaluate 用当前配置批改并与日志中的complete消息比较
+func (s *ReplayServiceV2) diffEvaluate(ctx context.Context, diff *replay.Diff, log *model.RawLogs, req *model.EvaluateRequest) (*replay.Diff, error) {
+	// 日志中保存的是complete消息，批改结果在data字段
+	var stored struct {
+		Data *model.EvaluateResponse `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(log.Response), &stored); err != nil || stored.Data == nil {
+		return diff, fmt.Errorf("解析原响应失败: %v", err)
+	}
+
+	current, err := s.evaluateService.Evaluate(ctx, req)
+	if err != nil {
+		return diff, fmt.Errorf("回放批改失败: %w", err)
+	}
+	return s.differ.DiffEvaluation(stored.Data, current), nil
+}
+
+func (s *ReplayServiceV2) replayOCR(ctx context.Context, log *model.RawLogs) (*replay.Diff, error) {
+	diff := &replay.Diff{Kind: replay.KindOCR}
+
+	// URL格式：/sts/ocr/title/:provider/:imgType 或 grpc:/essay.v1.EssayService/TitleOcr/:provider/:imgType，
+	// 早期gRPC日志没有记录provider和imgType，按空值（默认提供商）回放
+	var parts []string
+	switch {
+	case log.URL == grpcTitleOcrURL:
+		parts = []string{"", ""}
+	case strings.HasPrefix(log.URL, grpcTitleOcrURL+"/"):
+		parts = strings.Split(strings.TrimPrefix(log.URL, grpcTitleOcrURL+"/"), "/")
+	default:
+		parts = strings.Split(strings.TrimPrefix(log.URL, titleOcrURLPrefix), "/")
+	}
+	if len(parts) != 2 {
+		return diff, fmt.Errorf("无法解析OCR日志URL: %s", log.URL)
+	}
+
+	var req model.TitleOcrRequest
+	if err := json.Unmarshal([]byte(log.Request), &req); err != nil {
+		return diff, fmt.Errorf("解析请求失败: %w", err)
+	}
+
+	var stored model.TitleOcrResponse
+	if err := json.Unmarshal([]byte(log.Response), &stored); err != nil {
+		return diff, fmt.Errorf("解析原响应失败: %w", err)
+	}
+
+	current, err := s.ocrService.TitleOcr(ctx, parts[0], parts[1], &req)
+	if err != nil {
+		return diff, fmt.Errorf("回放OCR失败: %w", err)
+	}
+	return s.differ.DiffOCR(&stored, current), nil
+}
+
+func (s *ReplayServiceV2) replayStatistics(ctx context.Context, log *model.RawLogs) (*replay.Diff, error) {
+	diff := &replay.Diff{Kind: replay.KindStatistics}
+
+	var req model.ClassStatisticsRequest
+	if err := json.Unmarshal([]byte(log.Request), &req); err != nil {
+		return diff, fmt.Errorf("解析请求失败: %w", err)
+	}
+	// 与接口层相同的校验，空数据不进入统计分析
+	if len(req.SubmittedStudents) == 0 {
+		return diff, fmt.Errorf("学生数据为空")
+	}
+
+	var stored model.ClassStatisticsResponse
+	if err := json.Unmarshal([]byte(log.Response), &stored); err != nil {
+		return diff, fmt.Errorf("解析原响应失败: %w", err)
+	}
+
+	current, err := s.statisticsService.AnalyzeClassStatistics(ctx, req)
+	if err != nil {
+		return diff, fmt.Errorf("回放统计失败: %w", err)
+	}
+	return s.differ.DiffStatistics(&stored, current), nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/domain/replay"
+	"essay-stateless/internal/model"
+)
+
+func TestReplayTitleOcr(t *testing.T) {
+	fixture, err := os.ReadFile("../../../cmd/mock-upstream/fixtures/bee_ocr.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imageParams []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		for key := range body {
+			imageParams = append(imageParams, key)
+		}
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	ocrService := NewOcrServiceV2(&config.OCRConfig{DefaultProvider: "bee", BeeAPI: server.URL})
+	s := NewReplayServiceV2(nil, nil, ocrService, nil)
+
+	req := model.TitleOcrRequest{Images: []string{"https://img/1.jpg"}}
+	stored, err := ocrService.TitleOcr(context.Background(), "bee", "url", &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedJSON, _ := stored.JSONString()
+
+	tests := []struct {
+		name           string
+		url            string
+		wantImageParam string
+	}{
+		{"HTTP日志", "/sts/ocr/title/bee/url", "image_url"},
+		{"gRPC日志", "grpc:/essay.v1.EssayService/TitleOcr/bee/base64", "image_base64"},
+		{"gRPC日志未指定参数", "grpc:/essay.v1.EssayService/TitleOcr//", "image_"},
+		{"早期gRPC日志没有参数", "grpc:/essay.v1.EssayService/TitleOcr", "image_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageParams = nil
+			diff := s.Replay(context.Background(), &model.RawLogs{URL: tt.url, Request: req.JSONString(), Response: storedJSON})
+			if diff.Error != "" {
+				t.Fatalf("回放失败: %s", diff.Error)
+			}
+			if diff.Kind != replay.KindOCR || diff.Changed {
+				t.Errorf("Diff = %+v", diff)
+			}
+			if len(imageParams) != 1 || imageParams[0] != tt.wantImageParam {
+				t.Errorf("上游请求参数 = %v, want %s", imageParams, tt.wantImageParam)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"essay-stateless/internal/domain/report"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+	"io"
+)
+
+// ReportServiceV2 批改报告服务
+type ReportServiceV2 struct {
+	evaluateService *EvaluateServiceV2
+	htmlRenderer    *report.HTMLRenderer
+	docxRenderer    *report.DOCXRenderer
+}
+
+// NewReportServiceV2 创建批改报告服务，批改记录经evaluateService按用户查询
+func NewReportServiceV2(evaluateService *EvaluateServiceV2) *ReportServiceV2 {
+	return &ReportServiceV2{
+		evaluateService: evaluateService,
+		htmlRenderer:    report.NewHTMLRenderer(),
+		docxRenderer:    report.NewDOCXRenderer(),
+	}
+}
+
+// GetResult 查询该用户已持久化的批改结果，其他用户的记录视为不存在
+func (s *ReportServiceV2) GetResult(ctx context.Context, id, userID string) (*model.EvaluateResponse, error) {
+	evaluation, err := s.evaluateService.GetEvaluation(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Result == nil {
+		return nil, repository.ErrNotFound
+	}
+	return evaluation.Result, nil
+}
+
+// RenderHTML 渲染可打印的HTML报告
+func (s *ReportServiceV2) RenderHTML(w io.Writer, result *model.EvaluateResponse) error {
+	return s.htmlRenderer.Render(w, result)
+}
+
+// RenderDOCX 渲染带修订和批注的Word报告
+func (s *ReportServiceV2) RenderDOCX(w io.Writer, result *model.EvaluateResponse) error {
+	return s.docxRenderer.Render(w, result)
+}
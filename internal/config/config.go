@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -20,10 +21,22 @@ type Config struct {
 	Log      LogConfig      `mapstructure:"log"`
 	Trace    TraceConfig    `mapstructure:"trace"`
 	Lago     LagoConfig     `mapstructure:"lago"`
+	Cassette CassetteConfig `mapstructure:"cassette"`
 }
 
 type ServerConfig struct {
-	Port string `mapstructure:"port"`
+	Port       string       `mapstructure:"port"`
+	GRPCPort   string       `mapstructure:"grpc_port"`   // 为空时不启动gRPC服务
+	AdminToken string       `mapstructure:"admin_token"` // 管理接口令牌（X-Admin-Token），为空时管理接口不可用
+	Stream     StreamConfig `mapstructure:"stream"`
+}
+
+// StreamConfig 流式推送配置
+type StreamConfig struct {
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"` // SSE心跳间隔，防止代理空闲超时断开
+	RetryInterval     time.Duration `mapstructure:"retry_interval"`     // 建议浏览器断线重连间隔
+	PingInterval      time.Duration `mapstructure:"ping_interval"`      // WebSocket ping间隔
+	PongWait          time.Duration `mapstructure:"pong_wait"`          // WebSocket等待pong的超时时间
 }
 
 type DatabaseConfig struct {
@@ -32,8 +45,141 @@ type DatabaseConfig struct {
 }
 
 type EvaluateConfig struct {
-	API          EvaluateAPIConfig          `mapstructure:"api"`
-	ModelVersion EvaluateModelVersionConfig `mapstructure:"model_version"`
+	API                EvaluateAPIConfig          `mapstructure:"api"`
+	ModelVersion       EvaluateModelVersionConfig `mapstructure:"model_version"`
+	Shadow             ShadowConfig               `mapstructure:"shadow"`
+	Audit              AuditConfig                `mapstructure:"audit"`
+	ExamBand           ExamBandConfig             `mapstructure:"exam_band"`
+	Rubrics            []RubricConfig             `mapstructure:"rubrics"` // 可通过 rubricId 引用的评分量表
+	ScoreNormalization ScoreNormalizationConfig   `mapstructure:"score_normalization"`
+	OffTopic           OffTopicConfig             `mapstructure:"off_topic"`
+	ScoreEnsemble      ScoreEnsembleConfig        `mapstructure:"score_ensemble"`
+	GenreProfiles      []GenreProfileConfig       `mapstructure:"genre_profiles"` // 按文体选择的批改配置，按顺序取第一个匹配的
+}
+
+// GenreProfileConfig 按文体区分的批改配置
+type GenreProfileConfig struct {
+	Name       string             `mapstructure:"name"`
+	EssayTypes []string           `mapstructure:"essay_types"` // 适用的文体（请求指定或作文信息识别），为空时按name匹配
+	Steps      []string           `mapstructure:"steps"`       // 执行的并行步骤，为空时全部执行
+	Weights    GenreWeightsConfig `mapstructure:"weights"`     // 请求未指定各维度满分时，按权重把总分分配到各维度
+	Checks     []string           `mapstructure:"checks"`      // 文体专项检查：thesis / argument / explanation_method / narrative_elements
+	Messages   map[string]string  `mapstructure:"messages"`    // 步骤完成提示语，key为步骤名
+}
+
+// GenreWeightsConfig 各维度的相对权重，全为0时不分配
+type GenreWeightsConfig struct {
+	Content     int64 `mapstructure:"content"`
+	Expression  int64 `mapstructure:"expression"`
+	Structure   int64 `mapstructure:"structure"`
+	Development int64 `mapstructure:"development"`
+}
+
+// ScoreEnsembleConfig 集成评分：并行多次调用评分接口（或多个评分接口），取稳健统计量作为最终分数
+type ScoreEnsembleConfig struct {
+	Enabled            bool     `mapstructure:"enabled"`
+	Endpoints          []string `mapstructure:"endpoints"`            // 参与集成的评分接口，为空时使用 api.score
+	Runs               int      `mapstructure:"runs"`                 // 每个评分接口调用次数
+	Aggregate          string   `mapstructure:"aggregate"`            // median（默认）/ trimmed_mean
+	TrimRatio          float64  `mapstructure:"trim_ratio"`           // trimmed_mean 两端各去掉的比例
+	LowConfidenceRatio float64  `mapstructure:"low_confidence_ratio"` // 任一项分数极差超过其满分的该比例时标记低置信度，需教师复核
+}
+
+// OffTopicConfig 偏题/离题限分：切题度低于阈值时限制总分（可选限制内容分）
+type OffTopicConfig struct {
+	Enabled bool                 `mapstructure:"enabled"`
+	Rules   []OffTopicRuleConfig `mapstructure:"rules"` // 取切题度低于below的规则中最严格（below最小）的一条
+}
+
+type OffTopicRuleConfig struct {
+	Name            string  `mapstructure:"name"`              // 如 偏题、离题
+	Below           int     `mapstructure:"below"`             // 切题度（0~100）低于该值时适用
+	MaxRatio        float64 `mapstructure:"max_ratio"`         // 总分上限占满分的比例
+	ContentMaxRatio float64 `mapstructure:"content_max_ratio"` // 内容分上限占内容满分的比例，0表示不限制内容分
+}
+
+// ScoreNormalizationConfig 评分结果校验与归一化
+type ScoreNormalizationConfig struct {
+	Enabled       bool             `mapstructure:"enabled"`
+	TotalPolicy   string           `mapstructure:"total_policy"`   // 总分与各维度之和不一致时：sum 总分取各维度之和 / scale 各维度按总分等比调整 / keep 保留并记录
+	UpstreamScale ScoreScaleConfig `mapstructure:"upstream_scale"` // 上游固定分制，按请求满分折算
+}
+
+// ScoreScaleConfig 上游各项分数的固定满分，0表示上游已按请求满分打分
+type ScoreScaleConfig struct {
+	All         int64 `mapstructure:"all"`
+	Content     int64 `mapstructure:"content"`
+	Expression  int64 `mapstructure:"expression"`
+	Structure   int64 `mapstructure:"structure"`
+	Development int64 `mapstructure:"development"`
+	Appearance  int64 `mapstructure:"appearance"`
+}
+
+// RubricConfig 结构化评分量表
+type RubricConfig struct {
+	ID       string                  `mapstructure:"id"`
+	Name     string                  `mapstructure:"name"`
+	Criteria []RubricCriterionConfig `mapstructure:"criteria"`
+}
+
+type RubricCriterionConfig struct {
+	ID          string              `mapstructure:"id"`
+	Name        string              `mapstructure:"name"`
+	Description string              `mapstructure:"description"`
+	Dimension   string              `mapstructure:"dimension"`
+	MaxPoints   int64               `mapstructure:"max_points"`
+	Levels      []RubricLevelConfig `mapstructure:"levels"`
+}
+
+type RubricLevelConfig struct {
+	Name       string `mapstructure:"name"`
+	MinPoints  int64  `mapstructure:"min_points"`
+	Descriptor string `mapstructure:"descriptor"`
+}
+
+// ExamBandConfig 考试评分标准分类（一类文/二类文、基础等级/发展等级等）
+type ExamBandConfig struct {
+	Default   string               `mapstructure:"default"`   // 年级不在任何标准范围内时使用的标准ID，为空则不分类
+	Standards []ExamStandardConfig `mapstructure:"standards"` // 按顺序取第一个覆盖年级的标准
+}
+
+// ExamStandardConfig 一套考试评分标准
+type ExamStandardConfig struct {
+	ID         string                `mapstructure:"id"`
+	Name       string                `mapstructure:"name"`
+	MinGrade   int                   `mapstructure:"min_grade"`
+	MaxGrade   int                   `mapstructure:"max_grade"`
+	Bands      []BandRuleConfig      `mapstructure:"bands"`      // 按总分得分率分类，从高到低
+	Dimensions []DimensionBandConfig `mapstructure:"dimensions"` // 按维度得分率分等级
+}
+
+// DimensionBandConfig 单个维度的等级划分
+type DimensionBandConfig struct {
+	Dimension string           `mapstructure:"dimension"` // content/expression/structure/development
+	Group     string           `mapstructure:"group"`     // 所属等级组，如 基础等级、发展等级
+	Total     int64            `mapstructure:"total"`     // 请求未指定该维度总分时使用
+	Levels    []BandRuleConfig `mapstructure:"levels"`    // 从高到低
+}
+
+// BandRuleConfig 一个类别/等级：得分率不低于MinRatio即归入
+type BandRuleConfig struct {
+	Name       string  `mapstructure:"name"`
+	MinRatio   float64 `mapstructure:"min_ratio"` // 0~1
+	Descriptor string  `mapstructure:"descriptor"`
+}
+
+// AuditConfig 上游原始请求/响应审计归档配置
+type AuditConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ShadowConfig 影子流量配置：按比例把选定步骤同时发往候选上游，结果只落库不返回给用户
+type ShadowConfig struct {
+	Enabled       bool              `mapstructure:"enabled"`
+	Name          string            `mapstructure:"name"`           // 候选模型名称
+	SamplePercent float64           `mapstructure:"sample_percent"` // 采样比例（0~100）
+	Timeout       time.Duration     `mapstructure:"timeout"`        // 候选上游调用超时
+	API           EvaluateAPIConfig `mapstructure:"api"`            // 只填写需要影子调用的步骤，essay_info不参与
 }
 
 type EvaluateAPIConfig struct {
@@ -73,6 +219,12 @@ type TraceConfig struct {
 	Endpoint    string `mapstructure:"endpoint"`
 }
 
+// CassetteConfig 上游HTTP请求录制/回放配置
+type CassetteConfig struct {
+	Mode string `mapstructure:"mode"` // 为空不启用，record 录制，replay 回放（不访问网络）
+	Dir  string `mapstructure:"dir"`  // cassette文件目录
+}
+
 type LagoConfig struct {
 	APIKey  string `mapstructure:"api_key"`
 	BaseURL string `mapstructure:"base_url"`
@@ -116,6 +268,19 @@ func Load() *Config {
 
 func setDefaults() {
 	viper.SetDefault("server.port", ":8090")
+	viper.SetDefault("server.grpc_port", "")
+	viper.SetDefault("server.stream.heartbeat_interval", "15s")
+	viper.SetDefault("server.stream.retry_interval", "3s")
+	viper.SetDefault("server.stream.ping_interval", "30s")
+	viper.SetDefault("server.stream.pong_wait", "60s")
+	viper.SetDefault("evaluate.shadow.timeout", "5m")
+	viper.SetDefault("evaluate.audit.enabled", false)
+	viper.SetDefault("evaluate.score_normalization.enabled", true)
+	viper.SetDefault("evaluate.score_normalization.total_policy", "sum")
+	viper.SetDefault("evaluate.score_ensemble.runs", 3)
+	viper.SetDefault("evaluate.score_ensemble.aggregate", "median")
+	viper.SetDefault("evaluate.score_ensemble.trim_ratio", 0.2)
+	viper.SetDefault("evaluate.score_ensemble.low_confidence_ratio", 0.1)
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "json")
 	viper.SetDefault("trace.service_name", "essay-stateless")
@@ -2,12 +2,15 @@ package evaluate
 
 import (
 	"context"
+	"encoding/json"
+	"essay-stateless/internal/domain/rubric"
 	dto_evaluate "essay-stateless/internal/dto/evaluate"
 	"essay-stateless/internal/model"
 	"essay-stateless/pkg/httpclient"
 	"fmt"
 
 	"github.com/jinzhu/copier"
+	"github.com/sirupsen/logrus"
 )
 
 // APIClient 评估API客户端接口
@@ -48,6 +51,12 @@ func (c *EssayInfoClient) GetEssayInfo(ctx context.Context, req *model.EvaluateR
 
 	var response dto_evaluate.APIEssayInfo
 	if err := c.client.Post(ctx, c.apiURL, data, &response); err != nil {
+		return nil, fmt.Errorf("获取作文基本信息失败: %w", newTransportError("essay_info", err))
+	}
+	if err := firstError(
+		checkCode("essay_info", response.Code, response.Message),
+		requireField("essay_info", "sents", len(response.Sents) > 0),
+	); err != nil {
 		return nil, fmt.Errorf("获取作文基本信息失败: %w", err)
 	}
 	return &response, nil
@@ -67,6 +76,9 @@ func NewWordSentenceClient(apiURL string) *WordSentenceClient {
 func (c *WordSentenceClient) Evaluate(ctx context.Context, essay map[string]any) (*dto_evaluate.APIWordSentence, error) {
 	var response dto_evaluate.APIWordSentence
 	if err := c.client.Post(ctx, c.apiURL, essay, &response); err != nil {
+		return nil, fmt.Errorf("词句评估失败: %w", newTransportError("word_sentence", err))
+	}
+	if err := checkIntCode("word_sentence", response.Code, response.Message); err != nil {
 		return nil, fmt.Errorf("词句评估失败: %w", err)
 	}
 	return &response, nil
@@ -86,6 +98,9 @@ func NewGrammarClient(apiURL string) *GrammarClient {
 func (c *GrammarClient) Check(ctx context.Context, essay map[string]any) (*dto_evaluate.APIGrammarInfo, error) {
 	var response dto_evaluate.APIGrammarInfo
 	if err := c.client.Post(ctx, c.apiURL, essay, &response); err != nil {
+		return nil, fmt.Errorf("语法检查失败: %w", newTransportError("grammar", err))
+	}
+	if err := checkCode("grammar", response.Code, response.Message); err != nil {
 		return nil, fmt.Errorf("语法检查失败: %w", err)
 	}
 	return &response, nil
@@ -103,10 +118,24 @@ func NewOverallClient(apiURL string) *OverallClient {
 }
 
 func (c *OverallClient) Evaluate(ctx context.Context, essay map[string]any) (*dto_evaluate.APIOverall, error) {
+	var raw json.RawMessage
 	var response dto_evaluate.APIOverall
-	if err := c.client.Post(ctx, c.apiURL, essay, &response); err != nil {
+	if err := c.client.Post(ctx, c.apiURL, essay, &raw); err != nil {
+		return nil, fmt.Errorf("总体评价失败: %w", newTransportError("overall", err))
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("总体评价失败: %w", newTransportError("overall", err))
+	}
+	// 偏题判定只依赖切题度，缺少评语时保留切题度
+	if err := firstError(
+		checkCode("overall", response.Code, response.Message),
+		requireField("overall", "score", fieldPresent(raw, "score")),
+	); err != nil {
 		return nil, fmt.Errorf("总体评价失败: %w", err)
 	}
+	if response.Comment == "" {
+		logrus.Warn("总体评价缺少评语，仅使用切题度")
+	}
 	return &response, nil
 }
 
@@ -124,6 +153,12 @@ func NewSuggestionClient(apiURL string) *SuggestionClient {
 func (c *SuggestionClient) Generate(ctx context.Context, essay map[string]any) (*dto_evaluate.APISuggestion, error) {
 	var response dto_evaluate.APISuggestion
 	if err := c.client.Post(ctx, c.apiURL, essay, &response); err != nil {
+		return nil, fmt.Errorf("建议生成失败: %w", newTransportError("suggestion", err))
+	}
+	if err := firstError(
+		checkCode("suggestion", response.Code, response.Message),
+		requireField("suggestion", "comment", response.Comment != ""),
+	); err != nil {
 		return nil, fmt.Errorf("建议生成失败: %w", err)
 	}
 	return &response, nil
@@ -143,6 +178,12 @@ func NewParagraphClient(apiURL string) *ParagraphClient {
 func (c *ParagraphClient) Evaluate(ctx context.Context, essay map[string]any) (*dto_evaluate.APIParagraph, error) {
 	var response dto_evaluate.APIParagraph
 	if err := c.client.Post(ctx, c.apiURL, essay, &response); err != nil {
+		return nil, fmt.Errorf("段落评估失败: %w", newTransportError("paragraph", err))
+	}
+	if err := firstError(
+		checkCode("paragraph", response.Code, response.Message),
+		requireField("paragraph", "comments", len(response.Comments) > 0),
+	); err != nil {
 		return nil, fmt.Errorf("段落评估失败: %w", err)
 	}
 	return &response, nil
@@ -169,13 +210,23 @@ func (c *ScoreClient) Calculate(ctx context.Context, essay map[string]any, req *
 	if req.Prompt != nil {
 		scoreEssay["prompt"] = *req.Prompt
 	}
-	if req.Standard != nil {
+	// 结构化量表优先：渲染为文本兼容只认rubric文本的上游，同时附带结构化评分项供上游逐项打分
+	if req.Rubric != nil {
+		scoreEssay["rubric"] = rubric.Text(req.Rubric)
+		scoreEssay["rubric_criteria"] = req.Rubric.Criteria
+	} else if req.Standard != nil {
 		scoreEssay["rubric"] = *req.Standard
 	}
 
+	// 卷面分只在有原稿图片时评分
+	appearanceScore := req.AppearanceScore
+	if !req.HasImages() {
+		appearanceScore = nil
+	}
+
 	// 构建自定义分项打分比例
 	if req.ContentScore != nil || req.ExpressionScore != nil ||
-		req.StructureScore != nil || req.DevelopmentScore != nil {
+		req.StructureScore != nil || req.DevelopmentScore != nil || appearanceScore != nil {
 		ratio := make(map[string]any)
 		if req.ContentScore != nil {
 			ratio["content"] = *req.ContentScore
@@ -189,16 +240,38 @@ func (c *ScoreClient) Calculate(ctx context.Context, essay map[string]any, req *
 		if req.DevelopmentScore != nil {
 			ratio["development"] = *req.DevelopmentScore
 		}
+		if appearanceScore != nil {
+			ratio["appearance"] = *appearanceScore
+		}
 		scoreEssay["ratio"] = ratio
 	}
 
+	// image 为首页原稿，兼容只接收单张图片的上游；多页原稿同时放在 images 中
 	scoreEssay["image"] = ""
+	if req.HasImages() {
+		scoreEssay["image"] = req.Images[0]
+		scoreEssay["images"] = req.Images
+	}
 	scoreEssay["type"] = "essay"
 
+	var raw json.RawMessage
 	var response model.APIScore
-	if err := c.client.Post(ctx, c.apiURL, scoreEssay, &response); err != nil {
+	if err := c.client.Post(ctx, c.apiURL, scoreEssay, &raw); err != nil {
+		return nil, fmt.Errorf("评分计算失败: %w", newTransportError("score", err))
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("评分计算失败: %w", newTransportError("score", err))
+	}
+	// 各维度分数是评分结果的主体，缺少总评语时保留分数
+	if err := firstError(
+		checkCode("score", response.Code, response.Message),
+		requireField("score", "result.scores", fieldPresent(raw, "result", "scores")),
+	); err != nil {
 		return nil, fmt.Errorf("评分计算失败: %w", err)
 	}
+	if response.Result.Comment == "" {
+		logrus.Warn("评分结果缺少总评语，仅使用各维度分数")
+	}
 	return &response, nil
 }
 
@@ -216,7 +289,7 @@ func NewPolishingClient(apiURL string) *PolishingClient {
 func (c *PolishingClient) Polish(ctx context.Context, essay map[string]any) (*model.APIPolishingContent, error) {
 	var response model.APIPolishingContent
 	if err := c.client.Post(ctx, c.apiURL, essay, &response); err != nil {
-		return nil, fmt.Errorf("内容润色失败: %w", err)
+		return nil, fmt.Errorf("内容润色失败: %w", newTransportError("polishing", err))
 	}
 	return &response, nil
 }
@@ -224,7 +297,7 @@ func (c *PolishingClient) Polish(ctx context.Context, essay map[string]any) (*mo
 // PolishStream 流式润色（返回channel接收流式数据）
 func (c *PolishingClient) PolishStream(ctx context.Context, essay map[string]any, resultChan chan<- string) error {
 	if err := c.client.PostWithStream(ctx, c.apiURL, nil, essay, resultChan); err != nil {
-		return fmt.Errorf("流式润色失败: %w", err)
+		return fmt.Errorf("流式润色失败: %w", newTransportError("polishing", err))
 	}
 	return nil
 }
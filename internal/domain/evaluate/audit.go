@@ -0,0 +1,168 @@
+package evaluate
+
+import (
+	"context"
+	"encoding/json"
+	"essay-stateless/internal/model"
+	"essay-stateless/pkg/httpclient"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type (
+	auditStepKey    struct{}
+	auditAttemptKey struct{}
+	auditRunKey     struct{}
+)
+
+// withAuditStep 标记context所属的步骤，审计记录据此归类上游调用
+func withAuditStep(ctx context.Context, step string) context.Context {
+	return context.WithValue(ctx, auditStepKey{}, step)
+}
+
+// withAttempt 标记本次调用是该步骤的第几次尝试，由重试执行器设置
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, auditAttemptKey{}, attempt)
+}
+
+// withEnsembleRun 标记集成评分中的第几次调用，与重试无关
+func withEnsembleRun(ctx context.Context, run int) context.Context {
+	return context.WithValue(ctx, auditRunKey{}, run)
+}
+
+// withoutAudit 去掉context中的审计记录器（影子流量等不属于本次批改的调用）
+func withoutAudit(ctx context.Context) context.Context {
+	return httpclient.WithExchangeRecorder(ctx, nil)
+}
+
+// AuditRecorder 收集一次批改中所有上游调用的原始请求与响应
+type AuditRecorder struct {
+	mu        sync.Mutex
+	exchanges []model.AuditExchange
+}
+
+// NewAuditRecorder 创建审计记录器
+func NewAuditRecorder() *AuditRecorder {
+	return &AuditRecorder{}
+}
+
+// Attach 把记录器挂到context上，之后经由该context的上游调用都会被记录
+func (r *AuditRecorder) Attach(ctx context.Context) context.Context {
+	return httpclient.WithExchangeRecorder(ctx, r)
+}
+
+// RecordExchange 实现httpclient.ExchangeRecorder，尝试序号取自重试执行器，不经重试执行器的调用（如润色）记为1
+func (r *AuditRecorder) RecordExchange(ctx context.Context, exchange *httpclient.Exchange) {
+	step, _ := ctx.Value(auditStepKey{}).(string)
+	if step == "" {
+		step = exchange.URL
+	}
+	attempt, _ := ctx.Value(auditAttemptKey{}).(int)
+	run, _ := ctx.Value(auditRunKey{}).(int)
+
+	record := model.AuditExchange{
+		Step:         step,
+		Attempt:      max(attempt, 1),
+		Run:          run,
+		URL:          exchange.URL,
+		RequestBody:  redactImages(exchange.RequestBody),
+		StatusCode:   exchange.StatusCode,
+		ResponseBody: string(exchange.ResponseBody),
+		StreamChunks: exchange.StreamEvents,
+		StartTime:    exchange.Start,
+		LatencyMs:    exchange.Latency.Milliseconds(),
+	}
+	if exchange.Err != nil {
+		record.Error = exchange.Err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = append(r.exchanges, record)
+}
+
+// Exchanges 已记录的上游调用（按完成顺序）
+func (r *AuditRecorder) Exchanges() []model.AuditExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]model.AuditExchange(nil), r.exchanges...)
+}
+
+// minInlineImageLen 不带data URI前缀的base64串超过该长度才视为内联图片
+const minInlineImageLen = 1024
+
+// AuditRequest 写入审计记录的请求副本，内联的base64图片替换为占位说明，URL图片保留
+//
+// 原稿图片随每次重试和每次集成评分调用重复出现，原样保存容易超过MongoDB单个文档16MB的上限。
+func AuditRequest(req *model.EvaluateRequest) *model.EvaluateRequest {
+	if req == nil || !req.HasImages() {
+		return req
+	}
+	clone := *req
+	clone.Images = make([]string, len(req.Images))
+	for i, image := range req.Images {
+		clone.Images[i] = redactImage(image)
+	}
+	return &clone
+}
+
+// redactImages 替换JSON请求体中的内联图片，非JSON或不含内联图片时原样返回
+func redactImages(body []byte) string {
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return string(body)
+	}
+	payload, changed := redactValue(payload)
+	if !changed {
+		return string(body)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func redactValue(value any) (any, bool) {
+	changed := false
+	switch v := value.(type) {
+	case string:
+		if redacted := redactImage(v); redacted != v {
+			return redacted, true
+		}
+	case []any:
+		for i, item := range v {
+			var itemChanged bool
+			v[i], itemChanged = redactValue(item)
+			changed = changed || itemChanged
+		}
+	case map[string]any:
+		for key, item := range v {
+			var itemChanged bool
+			v[key], itemChanged = redactValue(item)
+			changed = changed || itemChanged
+		}
+	}
+	return value, changed
+}
+
+// redactImage data URI或较长的纯base64串替换为占位说明
+func redactImage(s string) string {
+	if !strings.HasPrefix(s, "data:image/") && (len(s) < minInlineImageLen || !isBase64(s)) {
+		return s
+	}
+	return fmt.Sprintf("[base64图片已省略，%d字节]", len(s))
+}
+
+func isBase64(s string) bool {
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '+', r == '/', r == '=', r == '\r', r == '\n':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package evaluate
+
+import (
+	"strings"
+	"testing"
+
+	"essay-stateless/internal/model"
+)
+
+func TestRedactImages(t *testing.T) {
+	inline := strings.Repeat("QUJD", 512)
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"没有图片", `{"essay":"今天下雨了。","image":""}`, `{"essay":"今天下雨了。","image":""}`},
+		{"URL图片保留", `{"image":"https://img/1.jpg","images":["https://img/1.jpg"]}`, `{"image":"https://img/1.jpg","images":["https://img/1.jpg"]}`},
+		{
+			"内联base64替换为占位",
+			`{"essay":"正文","image":"` + inline + `","images":["` + inline + `","https://img/2.jpg"]}`,
+			`{"essay":"正文","image":"[base64图片已省略，2048字节]","images":["[base64图片已省略，2048字节]","https://img/2.jpg"]}`,
+		},
+		{"data URI不论长短都替换", `{"image":"data:image/png;base64,iVBO"}`, `{"image":"[base64图片已省略，26字节]"}`},
+		{"较短的纯字母数字串不算图片", `{"type":"essay"}`, `{"type":"essay"}`},
+		{"非JSON原样保留", "not json", "not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactImages([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactImages = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditRequest(t *testing.T) {
+	inline := strings.Repeat("QUJD", 512)
+	req := &model.EvaluateRequest{Title: "我的妈妈", Images: []string{inline, "https://img/2.jpg"}}
+
+	got := AuditRequest(req)
+	if got.Images[0] != "[base64图片已省略，2048字节]" || got.Images[1] != "https://img/2.jpg" || got.Title != req.Title {
+		t.Errorf("AuditRequest = %+v", got)
+	}
+	if req.Images[0] != inline {
+		t.Error("AuditRequest 不应修改原请求")
+	}
+	if plain := (&model.EvaluateRequest{Title: "无图"}); AuditRequest(plain) != plain {
+		t.Error("没有图片时应直接返回原请求")
+	}
+}
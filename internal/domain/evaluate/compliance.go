@@ -0,0 +1,271 @@
+package evaluate
+
+import (
+	"essay-stateless/internal/model"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 题干要求类型
+const (
+	RequirementWordCount   = "word_count"
+	RequirementGenre       = "genre"
+	RequirementTitle       = "title"
+	RequirementPerspective = "perspective"
+)
+
+var (
+	// minWordsPattern 字数下限：不少于600字、不得少于六百字、至少600字、600字以上
+	minWordsPattern = regexp.MustCompile(`(?:不少于|不得少于|不低于|至少|最少)\s*([0-9０-９]+|[零一二两三四五六七八九十百千]+)\s*(?:个)?字|([0-9０-９]+|[零一二两三四五六七八九十百千]+)\s*字以上`)
+	// titlePattern 命题：以“母爱”为题、以《……的滋味》为题
+	titlePattern = regexp.MustCompile(`以\s*[“"《「『]([^”"》」』]+)[”"》」』]\s*为(?:题|标题|题目)`)
+	// titleBlankPattern 半命题中的待补全部分
+	titleBlankPattern = regexp.MustCompile(`_{2,}|＿{2,}|…+|—{2,}|（\s*）|\(\s*\)`)
+	// genres 可识别的文体
+	genres = []string{"记叙文", "议论文", "说明文", "散文", "应用文", "诗歌", "书信", "日记", "童话", "读后感", "演讲稿"}
+)
+
+// ComplianceAnalyzer 题干要求检查
+//
+// 从题干中识别字数下限、文体、命题/半命题和人称要求，分别对照作文信息中的字数、文体和作文标题、正文检查，
+// 只检查题干中明确提出的要求。
+type ComplianceAnalyzer struct{}
+
+// NewComplianceAnalyzer 创建题干要求检查
+func NewComplianceAnalyzer() *ComplianceAnalyzer {
+	return &ComplianceAnalyzer{}
+}
+
+// Analyze 检查作文是否符合题干要求，请求未提供题干或题干中没有可识别的要求时返回nil
+func (a *ComplianceAnalyzer) Analyze(req *model.EvaluateRequest, response *model.EvaluateResponse) *model.RequirementCompliance {
+	if req.Prompt == nil || strings.TrimSpace(*req.Prompt) == "" {
+		return nil
+	}
+	prompt := *req.Prompt
+
+	var checks []model.RequirementCheck
+	if check, ok := a.checkWordCount(prompt, req, response); ok {
+		checks = append(checks, check)
+	}
+	if check, ok := a.checkGenre(prompt, response); ok {
+		checks = append(checks, check)
+	}
+	if check, ok := a.checkTitle(prompt, req.Title); ok {
+		checks = append(checks, check)
+	}
+	if check, ok := a.checkPerspective(prompt, req.Content); ok {
+		checks = append(checks, check)
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+
+	compliance := &model.RequirementCompliance{AllMet: true, Requirements: checks}
+	for _, check := range checks {
+		if !check.Met {
+			compliance.AllMet = false
+		}
+	}
+	return compliance
+}
+
+// checkWordCount 字数取作文信息中的字数，作文信息未给出时按正文非空白字符计
+func (a *ComplianceAnalyzer) checkWordCount(prompt string, req *model.EvaluateRequest, response *model.EvaluateResponse) (model.RequirementCheck, bool) {
+	match := minWordsPattern.FindStringSubmatch(prompt)
+	if match == nil {
+		return model.RequirementCheck{}, false
+	}
+	number := match[1]
+	if number == "" {
+		number = match[2]
+	}
+	minWords, ok := parseCount(number)
+	if !ok || minWords <= 0 {
+		return model.RequirementCheck{}, false
+	}
+
+	count := response.EssayInfo.Counting.CharNum
+	if count == 0 {
+		count = countChars(req.Content)
+	}
+	check := model.RequirementCheck{
+		Kind:        RequirementWordCount,
+		Requirement: fmt.Sprintf("不少于%d字", minWords),
+		Met:         count >= minWords,
+	}
+	if check.Met {
+		check.Evidence = fmt.Sprintf("全文%d字", count)
+	} else {
+		check.Evidence = fmt.Sprintf("全文%d字，少%d字", count, minWords-count)
+	}
+	return check, true
+}
+
+// checkGenre 文体不限、除某文体外时不检查
+func (a *ComplianceAnalyzer) checkGenre(prompt string, response *model.EvaluateResponse) (model.RequirementCheck, bool) {
+	if strings.Contains(prompt, "文体不限") || strings.Contains(prompt, "文体自选") {
+		return model.RequirementCheck{}, false
+	}
+	var genre string
+	for _, clause := range clausePattern.Split(prompt, -1) {
+		if strings.Contains(clause, "除") {
+			continue
+		}
+		for _, name := range genres {
+			if strings.Contains(clause, name) {
+				genre = name
+				break
+			}
+		}
+		if genre != "" {
+			break
+		}
+	}
+	if genre == "" {
+		return model.RequirementCheck{}, false
+	}
+
+	check := model.RequirementCheck{Kind: RequirementGenre, Requirement: "文体为" + genre}
+	essayType := response.EssayInfo.EssayType
+	switch {
+	case essayType == "":
+		check.Evidence = "未识别出作文文体，无法确认"
+	case strings.Contains(essayType, genre) || strings.Contains(genre, essayType):
+		check.Met = true
+		check.Evidence = "识别文体为" + essayType
+	default:
+		check.Evidence = fmt.Sprintf("识别文体为%s，不是%s", essayType, genre)
+	}
+	return check, true
+}
+
+// checkTitle 命题要求标题一致，半命题要求标题补全了空白部分
+func (a *ComplianceAnalyzer) checkTitle(prompt, title string) (model.RequirementCheck, bool) {
+	if strings.Contains(prompt, "题目自拟") || strings.Contains(prompt, "自拟题目") {
+		return model.RequirementCheck{}, false
+	}
+	match := titlePattern.FindStringSubmatch(prompt)
+	if match == nil {
+		return model.RequirementCheck{}, false
+	}
+	required := strings.TrimSpace(match[1])
+	title = normalizeTitle(title)
+
+	check := model.RequirementCheck{Kind: RequirementTitle, Requirement: fmt.Sprintf("以“%s”为题", required)}
+	if title == "" {
+		check.Evidence = "作文没有标题"
+		return check, true
+	}
+
+	parts := titleBlankPattern.Split(required, 2)
+	if len(parts) == 2 {
+		// 半命题
+		prefix, suffix := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		check.Met = strings.HasPrefix(title, prefix) && strings.HasSuffix(title, suffix) &&
+			utf8.RuneCountInString(title) > utf8.RuneCountInString(prefix+suffix)
+		if check.Met {
+			blank := strings.TrimSuffix(strings.TrimPrefix(title, prefix), suffix)
+			check.Evidence = fmt.Sprintf("标题“%s”，补全为“%s”", title, blank)
+		} else {
+			check.Evidence = fmt.Sprintf("标题“%s”与半命题格式不符", title)
+		}
+		return check, true
+	}
+
+	check.Met = title == normalizeTitle(required)
+	if check.Met {
+		check.Evidence = fmt.Sprintf("标题“%s”", title)
+	} else {
+		check.Evidence = fmt.Sprintf("标题“%s”与要求不一致", title)
+	}
+	return check, true
+}
+
+// checkPerspective 人称按对话以外的叙述中“我”出现的次数判断
+func (a *ComplianceAnalyzer) checkPerspective(prompt, content string) (model.RequirementCheck, bool) {
+	var firstPerson bool
+	switch {
+	case strings.Contains(prompt, "第一人称") || strings.Contains(prompt, "“我”的") || strings.Contains(prompt, "我的视角"):
+		firstPerson = true
+	case strings.Contains(prompt, "第三人称"):
+		firstPerson = false
+	default:
+		return model.RequirementCheck{}, false
+	}
+
+	narration := dialoguePattern.ReplaceAllString(content, "")
+	first := strings.Count(narration, "我")
+	third := strings.Count(narration, "他") + strings.Count(narration, "她")
+	evidence := fmt.Sprintf("叙述中“我”出现%d次，“他/她”出现%d次", first, third)
+
+	if firstPerson {
+		return model.RequirementCheck{
+			Kind:        RequirementPerspective,
+			Requirement: "以第一人称叙述",
+			Met:         first >= 2,
+			Evidence:    evidence,
+		}, true
+	}
+	return model.RequirementCheck{
+		Kind:        RequirementPerspective,
+		Requirement: "以第三人称叙述",
+		Met:         first == 0,
+		Evidence:    evidence,
+	}, true
+}
+
+// dialoguePattern 引号中的对话，不计入叙述人称
+var dialoguePattern = regexp.MustCompile(`“[^”]*”|「[^」]*」`)
+
+// normalizeTitle 去掉书名号、引号和空白
+func normalizeTitle(title string) string {
+	return strings.TrimSpace(strings.NewReplacer("《", "", "》", "", "“", "", "”", "", "\"", "", " ", "", "　", "").Replace(title))
+}
+
+// countChars 非空白字符数
+func countChars(content string) int {
+	count := 0
+	for _, r := range content {
+		if !unicode.IsSpace(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// parseCount 解析阿拉伯数字（含全角）或中文数字，如 600、六百、一千二百
+func parseCount(text string) (int, bool) {
+	text = strings.Map(func(r rune) rune {
+		if r >= '０' && r <= '９' {
+			return r - '０' + '0'
+		}
+		return r
+	}, text)
+	if n, err := strconv.Atoi(text); err == nil {
+		return n, true
+	}
+
+	digits := map[rune]int{'零': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	units := map[rune]int{'十': 10, '百': 100, '千': 1000}
+	total, current := 0, 0
+	for _, r := range text {
+		if d, ok := digits[r]; ok {
+			current = d
+			continue
+		}
+		unit, ok := units[r]
+		if !ok {
+			return 0, false
+		}
+		if current == 0 {
+			current = 1 // 十二 = 一十二
+		}
+		total += current * unit
+		current = 0
+	}
+	return total + current, true
+}
@@ -0,0 +1,32 @@
+package evaluate
+
+import "testing"
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   int
+		wantOK bool
+	}{
+		{"600", 600, true},
+		{"８００", 800, true},
+		{"十", 10, true},
+		{"十二", 12, true},
+		{"六百", 600, true},
+		{"一千二百", 1200, true},
+		{"两千", 2000, true},
+		{"八百零五", 805, true},
+		{"三百五十", 350, true},
+		{"六百字", 0, false},
+		{"abc", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := parseCount(tt.text)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseCount(%q) = %d, %v, want %d, %v", tt.text, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
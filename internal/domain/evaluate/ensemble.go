@@ -0,0 +1,269 @@
+package evaluate
+
+import (
+	"cmp"
+	"context"
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/domain/rubric"
+	"essay-stateless/internal/model"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 集成评分的聚合方式
+const (
+	AggregateMedian      = "median"
+	AggregateTrimmedMean = "trimmed_mean"
+)
+
+// criterionSpreadPrefix 评分项在Confidence.Spreads中的字段前缀，后接评分项ID
+const criterionSpreadPrefix = "criterion:"
+
+// EnsembleScorer 集成评分：并行多次调用评分接口，按中位数或截尾均值聚合各项分数
+//
+// 评分项得分同样逐项聚合，评分项评语取该项得分最接近聚合结果的一次；总评语和各维度评语取总分最接近聚合结果的一次。
+// 各项分数（含评分项）的极差和标准差记录在Confidence中，任一项极差超过其满分的一定比例时标记低置信度，提示教师复核。
+type EnsembleScorer struct {
+	clients            []*ScoreClient
+	aggregate          string
+	trimRatio          float64
+	lowConfidenceRatio float64
+}
+
+// NewEnsembleScorer 创建集成评分，未启用或总调用次数不足2次时返回nil
+func NewEnsembleScorer(cfg config.ScoreEnsembleConfig, defaultEndpoint string) *EnsembleScorer {
+	if !cfg.Enabled {
+		return nil
+	}
+	endpoints := cfg.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{defaultEndpoint}
+	}
+	runs := max(cfg.Runs, 1)
+
+	scorer := &EnsembleScorer{
+		aggregate:          cfg.Aggregate,
+		trimRatio:          cfg.TrimRatio,
+		lowConfidenceRatio: cfg.LowConfidenceRatio,
+	}
+	for _, endpoint := range endpoints {
+		for range runs {
+			scorer.clients = append(scorer.clients, NewScoreClient(endpoint))
+		}
+	}
+	if len(scorer.clients) < 2 {
+		logrus.Warn("集成评分总调用次数不足2次，已忽略")
+		return nil
+	}
+	if scorer.aggregate != AggregateTrimmedMean {
+		scorer.aggregate = AggregateMedian
+	}
+	return scorer
+}
+
+// Calculate 并行调用各评分接口并聚合，全部失败时返回第一个错误；totals为上游分制下的满分，用于判断各次评分的分歧
+func (e *EnsembleScorer) Calculate(ctx context.Context, essay map[string]any, req *model.EvaluateRequest, totals rubric.Totals) (*model.APIScore, error) {
+	results := make([]*model.APIScore, len(e.clients))
+	errs := make([]error, len(e.clients))
+	var wg sync.WaitGroup
+	for i, client := range e.clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.Calculate(withEnsembleRun(ctx, i+1), essay, req)
+		}()
+	}
+	wg.Wait()
+
+	var scores []*model.APIScore
+	var firstErr error
+	for i, result := range results {
+		if errs[i] != nil {
+			logrus.Warnf("集成评分第 %d 次调用失败: %v", i+1, errs[i])
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		scores = append(scores, result)
+	}
+	if len(scores) == 0 {
+		return nil, firstErr
+	}
+
+	return e.combine(scores, len(e.clients)-len(scores), totals, req.Rubric), nil
+}
+
+// combine 聚合各项分数和评分项得分，以总分最接近聚合结果的一次作为总评语和维度评语的来源
+func (e *EnsembleScorer) combine(scores []*model.APIScore, failed int, totals rubric.Totals, scoringRubric *model.Rubric) *model.APIScore {
+	fields := []struct {
+		name  string
+		value func(*model.APIScore) *int64
+	}{
+		{"all", func(s *model.APIScore) *int64 { return &s.Result.Scores.All }},
+		{rubric.DimensionContent, func(s *model.APIScore) *int64 { return &s.Result.Scores.Content }},
+		{rubric.DimensionExpression, func(s *model.APIScore) *int64 { return &s.Result.Scores.Expression }},
+		{rubric.DimensionStructure, func(s *model.APIScore) *int64 { return &s.Result.Scores.Structure }},
+		{rubric.DimensionDevelopment, func(s *model.APIScore) *int64 { return &s.Result.Scores.Development }},
+		{rubric.DimensionAppearance, func(s *model.APIScore) *int64 { return &s.Result.Scores.Appearance }},
+	}
+
+	confidence := &model.ScoreConfidence{Runs: len(scores), Failed: failed, Aggregate: e.aggregate}
+	aggregated := make([]int64, len(fields))
+	for i, field := range fields {
+		values := make([]int64, len(scores))
+		for j, score := range scores {
+			values[j] = *field.value(score)
+		}
+		aggregated[i] = e.aggregateValues(values)
+		confidence.Spreads = append(confidence.Spreads, spreadOf(field.name, values, aggregated[i]))
+	}
+
+	representative := scores[0]
+	for _, score := range scores[1:] {
+		if absInt64(score.Result.Scores.All-aggregated[0]) < absInt64(representative.Result.Scores.All-aggregated[0]) {
+			representative = score
+		}
+	}
+	combined := *representative
+	for i, field := range fields {
+		*field.value(&combined) = aggregated[i]
+	}
+	combined.Result.Criteria = e.combineCriteria(scores, confidence)
+
+	e.assess(confidence, totals, scoringRubric)
+	combined.Confidence = confidence
+	return &combined
+}
+
+// combineCriteria 评分项按ID逐项聚合，按首次出现的顺序输出；只在部分次数中返回的评分项按返回的次数聚合
+func (e *EnsembleScorer) combineCriteria(scores []*model.APIScore, confidence *model.ScoreConfidence) []model.APIScoreCriterion {
+	var ids []string
+	byID := make(map[string][]model.APIScoreCriterion)
+	for _, score := range scores {
+		for _, criterion := range score.Result.Criteria {
+			if _, ok := byID[criterion.ID]; !ok {
+				ids = append(ids, criterion.ID)
+			}
+			byID[criterion.ID] = append(byID[criterion.ID], criterion)
+		}
+	}
+
+	criteria := make([]model.APIScoreCriterion, 0, len(ids))
+	for _, id := range ids {
+		runs := byID[id]
+		values := make([]int64, len(runs))
+		for i, criterion := range runs {
+			values[i] = criterion.Score
+		}
+		aggregated := e.aggregateValues(values)
+		confidence.Spreads = append(confidence.Spreads, spreadOf(criterionSpreadPrefix+id, values, aggregated))
+
+		representative := runs[0]
+		for _, criterion := range runs[1:] {
+			if absInt64(criterion.Score-aggregated) < absInt64(representative.Score-aggregated) {
+				representative = criterion
+			}
+		}
+		representative.Score = aggregated
+		criteria = append(criteria, representative)
+	}
+	return criteria
+}
+
+// assess 任一项极差超过其满分的lowConfidenceRatio时标记低置信度，满分未知的维度和评分项不参与判断
+func (e *EnsembleScorer) assess(confidence *model.ScoreConfidence, totals rubric.Totals, scoringRubric *model.Rubric) {
+	if e.lowConfidenceRatio <= 0 {
+		return
+	}
+	var reasons []string
+	for _, spread := range confidence.Spreads {
+		name, total := rubric.FieldName(spread.Field), totals.Dimensions[spread.Field]
+		if spread.Field == "all" {
+			total = totals.All
+		}
+		if id, ok := strings.CutPrefix(spread.Field, criterionSpreadPrefix); ok {
+			name, total = criterionNameAndMax(scoringRubric, id)
+		}
+		if total <= 0 || spread.Spread == 0 {
+			continue
+		}
+		if float64(spread.Spread) > e.lowConfidenceRatio*float64(total) {
+			reasons = append(reasons, fmt.Sprintf("%s各次评分相差 %d 分（满分 %d）", name, spread.Spread, total))
+		}
+	}
+	if len(reasons) > 0 {
+		confidence.LowConfidence = true
+		confidence.Reason = strings.Join(reasons, "；") + "，建议教师复核"
+	}
+}
+
+// criterionNameAndMax 量表中评分项的名称和分值，量表中没有该项时分值为0
+func criterionNameAndMax(scoringRubric *model.Rubric, id string) (string, int64) {
+	if scoringRubric != nil {
+		for _, criterion := range scoringRubric.Criteria {
+			if criterion.ID == id {
+				return cmp.Or(criterion.Name, id), criterion.MaxPoints
+			}
+		}
+	}
+	return id, 0
+}
+
+// aggregateValues 中位数或截尾均值，四舍五入取整
+func (e *EnsembleScorer) aggregateValues(values []int64) int64 {
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	if e.aggregate == AggregateTrimmedMean {
+		trim := int(float64(len(sorted)) * e.trimRatio)
+		if len(sorted)-2*trim < 1 {
+			trim = (len(sorted) - 1) / 2
+		}
+		kept := sorted[trim : len(sorted)-trim]
+		var sum int64
+		for _, v := range kept {
+			sum += v
+		}
+		return int64(math.Round(float64(sum) / float64(len(kept))))
+	}
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return int64(math.Round(float64(sorted[mid-1]+sorted[mid]) / 2))
+}
+
+func spreadOf(field string, values []int64, aggregated int64) model.ScoreSpread {
+	minValue, maxValue := values[0], values[0]
+	var sum float64
+	for _, v := range values {
+		minValue, maxValue = min(minValue, v), max(maxValue, v)
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return model.ScoreSpread{
+		Field:      field,
+		Values:     values,
+		Aggregated: aggregated,
+		Spread:     maxValue - minValue,
+		StdDev:     math.Round(math.Sqrt(variance/float64(len(values)))*100) / 100,
+	}
+}
+
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
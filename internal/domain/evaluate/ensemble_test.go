@@ -0,0 +1,178 @@
+package evaluate
+
+import (
+	"reflect"
+	"testing"
+
+	"essay-stateless/internal/domain/rubric"
+	"essay-stateless/internal/model"
+)
+
+func TestAggregateValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		aggregate string
+		trimRatio float64
+		values    []int64
+		want      int64
+	}{
+		{"中位数奇数个", AggregateMedian, 0, []int64{5, 1, 3}, 3},
+		{"中位数偶数个取中间两个的均值", AggregateMedian, 0, []int64{40, 10, 20, 30}, 25},
+		{"中位数偶数个均值四舍五入", AggregateMedian, 0, []int64{1, 2, 3, 4}, 3},
+		{"单个值", AggregateMedian, 0, []int64{7}, 7},
+		{"截尾均值去掉两端", AggregateTrimmedMean, 0.2, []int64{100, 2, 3, 4, 1}, 3},
+		{"截尾均值不截尾", AggregateTrimmedMean, 0, []int64{1, 2, 6}, 3},
+		{"截尾会去掉全部时保留中间一个", AggregateTrimmedMean, 0.5, []int64{1, 5, 9}, 5},
+		{"截尾会去掉全部时偶数个保留中间两个", AggregateTrimmedMean, 0.9, []int64{1, 4, 6, 20}, 5},
+		{"两个值截尾过多时取均值", AggregateTrimmedMean, 0.5, []int64{1, 4}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &EnsembleScorer{aggregate: tt.aggregate, trimRatio: tt.trimRatio}
+			if got := e.aggregateValues(tt.values); got != tt.want {
+				t.Errorf("aggregateValues(%v) = %d, want %d", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombineCriteria(t *testing.T) {
+	run := func(criteria ...model.APIScoreCriterion) *model.APIScore {
+		score := &model.APIScore{}
+		score.Result.Criteria = criteria
+		return score
+	}
+	tests := []struct {
+		name        string
+		scores      []*model.APIScore
+		want        []model.APIScoreCriterion
+		wantSpreads []model.ScoreSpread
+	}{
+		{
+			name:   "没有评分项",
+			scores: []*model.APIScore{run(), run()},
+			want:   []model.APIScoreCriterion{},
+		},
+		{
+			name: "逐项取中位数，评语取最接近的一次",
+			scores: []*model.APIScore{
+				run(model.APIScoreCriterion{ID: "a", Score: 10, Comment: "x"}),
+				run(model.APIScoreCriterion{ID: "a", Score: 18, Comment: "y"}),
+				run(model.APIScoreCriterion{ID: "a", Score: 12, Comment: "z"}),
+			},
+			want: []model.APIScoreCriterion{{ID: "a", Score: 12, Comment: "z"}},
+			wantSpreads: []model.ScoreSpread{
+				{Field: "criterion:a", Values: []int64{10, 18, 12}, Aggregated: 12, Spread: 8, StdDev: 3.4},
+			},
+		},
+		{
+			name: "只在部分次数中返回的评分项按返回的次数聚合",
+			scores: []*model.APIScore{
+				run(model.APIScoreCriterion{ID: "a", Score: 10, Comment: "x"}),
+				run(model.APIScoreCriterion{ID: "a", Score: 12, Comment: "y"}, model.APIScoreCriterion{ID: "b", Score: 4, Comment: "b1"}),
+				run(model.APIScoreCriterion{ID: "b", Score: 6, Comment: "b2"}, model.APIScoreCriterion{ID: "a", Score: 11, Comment: "z"}),
+			},
+			want: []model.APIScoreCriterion{
+				{ID: "a", Score: 11, Comment: "z"},
+				{ID: "b", Score: 5, Comment: "b1"},
+			},
+			wantSpreads: []model.ScoreSpread{
+				{Field: "criterion:a", Values: []int64{10, 12, 11}, Aggregated: 11, Spread: 2, StdDev: 0.82},
+				{Field: "criterion:b", Values: []int64{4, 6}, Aggregated: 5, Spread: 2, StdDev: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &EnsembleScorer{aggregate: AggregateMedian}
+			confidence := &model.ScoreConfidence{}
+			got := e.combineCriteria(tt.scores, confidence)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("评分项 = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(confidence.Spreads, tt.wantSpreads) {
+				t.Errorf("分布 = %+v, want %+v", confidence.Spreads, tt.wantSpreads)
+			}
+		})
+	}
+}
+
+func TestAssess(t *testing.T) {
+	totals := rubric.Totals{All: 50, Dimensions: map[string]int64{rubric.DimensionContent: 20}}
+	scoringRubric := &model.Rubric{Criteria: []model.RubricCriterion{{ID: "a", Name: "立意", MaxPoints: 10}}}
+	tests := []struct {
+		name       string
+		ratio      float64
+		spreads    []model.ScoreSpread
+		totals     rubric.Totals
+		wantLow    bool
+		wantReason string
+	}{
+		{
+			name:    "分歧在阈值内",
+			ratio:   0.1,
+			spreads: []model.ScoreSpread{{Field: "all", Spread: 5}, {Field: rubric.DimensionContent, Spread: 2}},
+			totals:  totals,
+		},
+		{
+			name:       "总分分歧超过阈值",
+			ratio:      0.1,
+			spreads:    []model.ScoreSpread{{Field: "all", Spread: 6}},
+			totals:     totals,
+			wantLow:    true,
+			wantReason: "总分各次评分相差 6 分（满分 50），建议教师复核",
+		},
+		{
+			name:       "多项超过阈值",
+			ratio:      0.1,
+			spreads:    []model.ScoreSpread{{Field: "all", Spread: 6}, {Field: rubric.DimensionContent, Spread: 3}},
+			totals:     totals,
+			wantLow:    true,
+			wantReason: "总分各次评分相差 6 分（满分 50）；内容各次评分相差 3 分（满分 20），建议教师复核",
+		},
+		{
+			name:    "满分未知的维度不参与判断",
+			ratio:   0.1,
+			spreads: []model.ScoreSpread{{Field: rubric.DimensionStructure, Spread: 30}},
+			totals:  totals,
+		},
+		{
+			name:       "评分项按分值判断",
+			ratio:      0.1,
+			spreads:    []model.ScoreSpread{{Field: "criterion:a", Spread: 2}},
+			totals:     totals,
+			wantLow:    true,
+			wantReason: "立意各次评分相差 2 分（满分 10），建议教师复核",
+		},
+		{
+			name:    "量表中没有的评分项不参与判断",
+			ratio:   0.1,
+			spreads: []model.ScoreSpread{{Field: "criterion:x", Spread: 9}},
+			totals:  totals,
+		},
+		{
+			name:    "按上游分制的满分判断",
+			ratio:   0.1,
+			spreads: []model.ScoreSpread{{Field: "all", Spread: 8}},
+			totals:  rubric.Totals{All: 100},
+		},
+		{
+			name:    "阈值为0时不判断",
+			spreads: []model.ScoreSpread{{Field: "all", Spread: 50}},
+			totals:  totals,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &EnsembleScorer{lowConfidenceRatio: tt.ratio}
+			confidence := &model.ScoreConfidence{Spreads: tt.spreads}
+			e.assess(confidence, tt.totals, scoringRubric)
+			if confidence.LowConfidence != tt.wantLow || confidence.Reason != tt.wantReason {
+				t.Errorf("assess = %v %q, want %v %q", confidence.LowConfidence, confidence.Reason, tt.wantLow, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package evaluate
+
+import (
+	"essay-stateless/internal/model"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 依据来源
+const (
+	EvidenceQuote            = "quote"
+	EvidenceGoodSentence     = "good_sentence"
+	EvidenceGoodWord         = "good_word"
+	EvidenceMistake          = "mistake"
+	EvidenceParagraphComment = "paragraph_comment"
+)
+
+// 依据倾向
+const (
+	EvidencePositive = "positive"
+	EvidenceNegative = "negative"
+)
+
+// maxEvidencePerDimension 每个维度最多给出的依据数
+const maxEvidencePerDimension = 6
+
+// dimensionKeywords 段落点评、好句标签中出现这些词时，视为对应维度的依据
+var dimensionKeywords = map[string][]string{
+	"content":     {"内容", "选材", "材料", "中心", "主题", "立意", "事例", "感情", "情感", "真挚", "题目", "点题", "重点"},
+	"expression":  {"语言", "用词", "词语", "修辞", "比喻", "拟人", "排比", "生动", "通顺", "句子", "错别字", "应为"},
+	"structure":   {"结构", "开头", "结尾", "过渡", "层次", "首尾", "呼应", "照应", "段落", "详略", "条理"},
+	"development": {"细节", "描写", "深刻", "新颖", "创意", "丰富", "联想", "想象", "感悟", "独特"},
+}
+
+// negativeCues 评语中出现这些词时视为指出不足，优先给出“还需努力”类依据
+var negativeCues = []string{"不足", "欠缺", "有误", "错误", "还需", "不够", "可以更", "建议", "略显", "稍显", "不太", "单薄", "较弱", "注意", "应为"}
+
+// quotePattern 评语中引用的原文片段
+var quotePattern = regexp.MustCompile(`[“"「]([^”"」]{2,})[”"」]`)
+
+// clausePattern 分句标点
+var clausePattern = regexp.MustCompile(`[，。；！？,;!?]`)
+
+// EvidenceLinker 为分数评语关联原文依据
+//
+// 启发式：评语中引用的原文片段直接定位；表达维度关联好词好句与还需努力的词；
+// 其他维度按关键词关联段落点评和好句标签，结构维度另按“开头/结尾”定位首末段。
+// 依据与评语倾向一致的排在前面。所有步骤完成后调用一次。
+type EvidenceLinker struct{}
+
+// NewEvidenceLinker 创建依据关联器
+func NewEvidenceLinker() *EvidenceLinker {
+	return &EvidenceLinker{}
+}
+
+// Link 为各维度评语写入依据，评语为空的维度不关联
+func (l *EvidenceLinker) Link(response *model.EvaluateResponse) {
+	scoreEvaluation := &response.AIEvaluation.ScoreEvaluation
+	comments := map[string]string{
+		"content":     scoreEvaluation.Comments.Content,
+		"expression":  scoreEvaluation.Comments.Expression,
+		"structure":   scoreEvaluation.Comments.Structure,
+		"development": scoreEvaluation.Comments.Development,
+	}
+
+	evidence := make(map[string][]model.EvidenceRef)
+	for dimension, comment := range comments {
+		if comment == "" {
+			continue
+		}
+		if refs := l.linkDimension(response, dimension, comment); len(refs) > 0 {
+			evidence[dimension] = refs
+		}
+	}
+	if len(evidence) > 0 {
+		scoreEvaluation.Evidence = evidence
+	}
+}
+
+func (l *EvidenceLinker) linkDimension(response *model.EvaluateResponse, dimension, comment string) []model.EvidenceRef {
+	polarity := commentPolarity(comment)
+	var quoted, matched, others []model.EvidenceRef
+	add := func(ref model.EvidenceRef) {
+		if ref.Polarity == polarity {
+			matched = append(matched, ref)
+		} else {
+			others = append(others, ref)
+		}
+	}
+
+	// 评语中引用的原文片段
+	for _, fragment := range quotedFragments(comment) {
+		if ref, ok := locateFragment(response.Text, fragment, -1); ok {
+			ref.Polarity = polarity
+			quoted = append(quoted, ref)
+		}
+	}
+
+	sentences := response.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations
+	for p, paragraph := range sentences {
+		for s, sentence := range paragraph {
+			text := sentenceText(response.Text, p, s)
+			if sentence.IsGoodSentence && (dimension == "expression" || containsAny(sentence.Label, dimensionKeywords[dimension])) {
+				add(model.EvidenceRef{
+					Source: EvidenceGoodSentence, Polarity: EvidencePositive,
+					ParagraphIndex: p, SentenceIndex: s, Text: text, Note: sentence.Label,
+				})
+			}
+			if dimension != "expression" {
+				continue
+			}
+			for _, word := range sentence.WordEvaluations {
+				ref := model.EvidenceRef{
+					ParagraphIndex: p, SentenceIndex: s, Span: word.Span,
+					Text: substring(text, word.Span), Note: word.Type["level2"],
+				}
+				if word.Type["level1"] == "还需努力" {
+					ref.Source, ref.Polarity = EvidenceMistake, EvidenceNegative
+					if word.Ori != "" {
+						ref.Text = word.Ori
+					}
+				} else {
+					ref.Source, ref.Polarity = EvidenceGoodWord, EvidencePositive
+				}
+				add(ref)
+			}
+		}
+	}
+
+	// 段落点评常褒贬并存，按分句匹配维度关键词，倾向和引文只看命中的分句
+	for _, paragraph := range response.AIEvaluation.ParagraphEvaluations {
+		clause := matchingClauses(paragraph.Comment, dimensionKeywords[dimension])
+		if clause == "" {
+			continue
+		}
+		ref := model.EvidenceRef{
+			Source: EvidenceParagraphComment, Polarity: commentPolarity(clause),
+			ParagraphIndex: paragraph.ParagraphIndex, SentenceIndex: -1, Note: paragraph.Comment,
+		}
+		// 点评引用了原文时定位到具体句子
+		for _, fragment := range quotedFragments(clause) {
+			if located, ok := locateFragment(response.Text, fragment, paragraph.ParagraphIndex); ok {
+				ref.SentenceIndex, ref.Span, ref.Text = located.SentenceIndex, located.Span, located.Text
+				break
+			}
+		}
+		add(ref)
+	}
+
+	// 评语提到开头、结尾但没有对应段落的点评时，直接指向首末段
+	if dimension == "structure" && len(response.Text) > 0 {
+		last := len(response.Text) - 1
+		if containsAny(comment, []string{"开头", "开篇", "首尾"}) && !hasParagraph(matched, others, 0) {
+			add(paragraphRef(0, polarity))
+		}
+		if last > 0 && containsAny(comment, []string{"结尾", "首尾"}) && !hasParagraph(matched, others, last) {
+			add(paragraphRef(last, polarity))
+		}
+	}
+
+	refs := append(append(quoted, matched...), others...)
+	refs = dedupeEvidence(refs)
+	if len(refs) > maxEvidencePerDimension {
+		refs = refs[:maxEvidencePerDimension]
+	}
+	return refs
+}
+
+// commentPolarity 评语含指出不足的词时为negative
+func commentPolarity(comment string) string {
+	if containsAny(comment, negativeCues) {
+		return EvidenceNegative
+	}
+	return EvidencePositive
+}
+
+func quotedFragments(comment string) []string {
+	var fragments []string
+	for _, match := range quotePattern.FindAllStringSubmatch(comment, -1) {
+		fragments = append(fragments, match[1])
+	}
+	return fragments
+}
+
+// locateFragment 在原文中查找片段，paragraphIndex>=0时只在该段查找
+func locateFragment(text [][]string, fragment string, paragraphIndex int) (model.EvidenceRef, bool) {
+	for p, paragraph := range text {
+		if paragraphIndex >= 0 && p != paragraphIndex {
+			continue
+		}
+		for s, sentence := range paragraph {
+			idx := strings.Index(sentence, fragment)
+			if idx < 0 {
+				continue
+			}
+			start := utf8.RuneCountInString(sentence[:idx])
+			return model.EvidenceRef{
+				Source:         EvidenceQuote,
+				ParagraphIndex: p,
+				SentenceIndex:  s,
+				Span:           []int{start, start + utf8.RuneCountInString(fragment)},
+				Text:           fragment,
+			}, true
+		}
+	}
+	return model.EvidenceRef{}, false
+}
+
+// matchingClauses 点评中含关键词的分句，用逗号连接
+func matchingClauses(comment string, keywords []string) string {
+	var clauses []string
+	for _, clause := range clausePattern.Split(comment, -1) {
+		if containsAny(clause, keywords) {
+			clauses = append(clauses, clause)
+		}
+	}
+	return strings.Join(clauses, "，")
+}
+
+func hasParagraph(matched, others []model.EvidenceRef, paragraphIndex int) bool {
+	for _, refs := range [][]model.EvidenceRef{matched, others} {
+		for _, ref := range refs {
+			if ref.ParagraphIndex == paragraphIndex {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func paragraphRef(paragraphIndex int, polarity string) model.EvidenceRef {
+	return model.EvidenceRef{
+		Source:         EvidenceParagraphComment,
+		Polarity:       polarity,
+		ParagraphIndex: paragraphIndex,
+		SentenceIndex:  -1,
+	}
+}
+
+// dedupeEvidence 同一位置只保留第一条（优先级更高的）
+func dedupeEvidence(refs []model.EvidenceRef) []model.EvidenceRef {
+	type key struct {
+		paragraph, sentence, start int
+	}
+	seen := make(map[key]bool)
+	var result []model.EvidenceRef
+	for _, ref := range refs {
+		k := key{ref.ParagraphIndex, ref.SentenceIndex, -1}
+		if len(ref.Span) > 0 {
+			k.start = ref.Span[0]
+		}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, ref)
+	}
+	return result
+}
+
+func sentenceText(text [][]string, p, s int) string {
+	if p < len(text) && s < len(text[p]) {
+		return text[p][s]
+	}
+	return ""
+}
+
+// substring 按字符区间截取
+func substring(text string, span []int) string {
+	runes := []rune(text)
+	if len(span) != 2 || span[0] < 0 || span[1] > len(runes) || span[0] >= span[1] {
+		return ""
+	}
+	return string(runes[span[0]:span[1]])
+}
+
+func containsAny(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package evaluate
+
+import (
+	"context"
+	"essay-stateless/internal/model"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LatencyStore 步骤耗时统计的持久化接口
+type LatencyStore interface {
+	FindAll(ctx context.Context) ([]model.StepLatency, error)
+	Upsert(ctx context.Context, latency *model.StepLatency) error
+}
+
+// latencyAlpha 指数加权系数，越大越偏向最近的样本
+const latencyAlpha = 0.2
+
+// defaultStepLatency 无历史数据时各步骤的预估耗时
+var defaultStepLatency = map[string]time.Duration{
+	"essay_info":    3 * time.Second,
+	"word_sentence": 10 * time.Second,
+	"grammar":       8 * time.Second,
+	"overall":       10 * time.Second,
+	"suggestion":    10 * time.Second,
+	"paragraph":     12 * time.Second,
+	"score":         15 * time.Second,
+	"polishing":     30 * time.Second,
+}
+
+// LatencyTracker 步骤耗时跟踪器
+//
+// 按步骤维护指数加权的耗时均值，用于进度加权和剩余时间估算，统计结果持久化到MongoDB
+type LatencyTracker struct {
+	mu    sync.RWMutex
+	stats map[string]*model.StepLatency
+	store LatencyStore
+}
+
+// NewLatencyTracker 创建耗时跟踪器，store为nil时仅在内存中统计
+func NewLatencyTracker(store LatencyStore) *LatencyTracker {
+	return &LatencyTracker{
+		stats: make(map[string]*model.StepLatency),
+		store: store,
+	}
+}
+
+// Load 从持久化存储加载历史统计
+func (t *LatencyTracker) Load(ctx context.Context) error {
+	if t.store == nil {
+		return nil
+	}
+
+	latencies, err := t.store.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range latencies {
+		latency := latencies[i]
+		t.stats[latency.Step] = &latency
+	}
+	logrus.Infof("加载步骤耗时统计 %d 条", len(latencies))
+	return nil
+}
+
+// Observe 记录一次步骤耗时
+func (t *LatencyTracker) Observe(step string, elapsed time.Duration) {
+	ms := float64(elapsed.Milliseconds())
+
+	t.mu.Lock()
+	stat, ok := t.stats[step]
+	if !ok {
+		stat = &model.StepLatency{Step: step, MeanMs: ms}
+		t.stats[step] = stat
+	}
+	stat.Count++
+	stat.DeviationMs = (1-latencyAlpha)*stat.DeviationMs + latencyAlpha*math.Abs(ms-stat.MeanMs)
+	stat.MeanMs = (1-latencyAlpha)*stat.MeanMs + latencyAlpha*ms
+	stat.UpdateTime = time.Now()
+	snapshot := *stat
+	t.mu.Unlock()
+
+	if t.store == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := t.store.Upsert(ctx, &snapshot); err != nil {
+			logrus.Errorf("保存步骤耗时统计失败 [%s]: %v", step, err)
+		}
+	}()
+}
+
+// Expected 步骤的预估耗时
+func (t *LatencyTracker) Expected(step string) time.Duration {
+	t.mu.RLock()
+	stat, ok := t.stats[step]
+	t.mu.RUnlock()
+
+	if ok && stat.Count > 0 {
+		return time.Duration(stat.MeanMs) * time.Millisecond
+	}
+	if d, ok := defaultStepLatency[step]; ok {
+		return d
+	}
+	return 10 * time.Second
+}
+
+// NewProgress 为一次评估创建进度估算器
+func (t *LatencyTracker) NewProgress(parallelSteps []string) *ProgressEstimator {
+	p := &ProgressEstimator{
+		tracker:  t,
+		start:    time.Now(),
+		expected: make(map[string]time.Duration, len(parallelSteps)),
+		pending:  make(map[string]bool, len(parallelSteps)),
+	}
+	for _, step := range parallelSteps {
+		d := t.Expected(step)
+		p.expected[step] = d
+		p.pending[step] = true
+		p.totalWeight += d.Seconds()
+	}
+	return p
+}
+
+// ProgressEstimator 单次评估的进度与剩余时间估算
+//
+// essay_info完成后进度为15，其余并行步骤按预估耗时加权占据15-90区间
+type ProgressEstimator struct {
+	tracker       *LatencyTracker
+	start         time.Time
+	parallelStart time.Time
+	expected      map[string]time.Duration
+	pending       map[string]bool
+	totalWeight   float64
+	doneWeight    float64
+}
+
+const (
+	baseProgress  = 15 // essay_info完成后的进度
+	progressRange = 75 // 从15到90的范围
+)
+
+// StartParallel 标记并行步骤开始
+func (p *ProgressEstimator) StartParallel() {
+	p.parallelStart = time.Now()
+}
+
+// Skip 本次评估不执行的步骤，不计入进度和ETA
+func (p *ProgressEstimator) Skip(step string) {
+	if p.pending[step] {
+		delete(p.pending, step)
+		p.totalWeight -= p.expected[step].Seconds()
+	}
+}
+
+// Complete 标记步骤完成（成功或失败），返回最新进度
+func (p *ProgressEstimator) Complete(step string) int {
+	if p.pending[step] {
+		delete(p.pending, step)
+		p.doneWeight += p.expected[step].Seconds()
+	}
+	return p.Progress()
+}
+
+// Progress 当前进度
+func (p *ProgressEstimator) Progress() int {
+	if p.parallelStart.IsZero() {
+		return 0
+	}
+	if p.totalWeight == 0 {
+		return baseProgress + progressRange
+	}
+	return baseProgress + int(p.doneWeight/p.totalWeight*progressRange)
+}
+
+// ETASeconds 预计剩余秒数：并行步骤中剩余耗时最长者决定整体完成时间
+func (p *ProgressEstimator) ETASeconds() int {
+	var remaining time.Duration
+	if p.parallelStart.IsZero() {
+		remaining = p.tracker.Expected("essay_info") - time.Since(p.start)
+		if remaining < 0 {
+			remaining = 0
+		}
+		remaining += p.maxExpected()
+	} else {
+		elapsed := time.Since(p.parallelStart)
+		for step := range p.pending {
+			r := p.expected[step] - elapsed
+			if r < time.Second {
+				// 已超过预估耗时的步骤至少再留1秒，避免ETA过早归零
+				r = time.Second
+			}
+			if r > remaining {
+				remaining = r
+			}
+		}
+	}
+	return int(math.Ceil(remaining.Seconds()))
+}
+
+func (p *ProgressEstimator) maxExpected() time.Duration {
+	var max time.Duration
+	for step := range p.pending {
+		if p.expected[step] > max {
+			max = p.expected[step]
+		}
+	}
+	return max
+}
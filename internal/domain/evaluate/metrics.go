@@ -0,0 +1,118 @@
+package evaluate
+
+import (
+	_ "embed"
+	dto_evaluate "essay-stateless/internal/dto/evaluate"
+	"essay-stateless/internal/model"
+	"strings"
+	"unicode"
+)
+
+//go:embed idioms.txt
+var idiomData string
+
+// EssayInfoSourceLocal 作文信息由本地估算
+const EssayInfoSourceLocal = "local"
+
+// 本地估算的计数与作文信息服务口径不同：句子按标点切分，词数按不同汉字数，成语按内置词表匹配
+var approximateCounts = []string{"sentNum", "uniqueWordNum", "idiomNum"}
+
+// 本地无法计算的计数，置为0
+var unavailableCounts = []string{
+	"adjAdvNum", "dieciNum", "fluency", "grammarMistakeNum", "highlightSentsNum",
+	"nounTypeNum", "verbTypeNum", "wordNum", "writtenMistakeNum",
+}
+
+// TextMetrics 本地文本统计，作文信息服务不可用时代替其给出分段分句和基础计数
+type TextMetrics struct {
+	processor   *ResponseProcessor
+	idioms      map[string]bool
+	maxIdiomLen int
+	minIdiomLen int
+}
+
+// NewTextMetrics 创建本地文本统计，加载内置成语词表
+func NewTextMetrics(processor *ResponseProcessor) *TextMetrics {
+	m := &TextMetrics{processor: processor, idioms: make(map[string]bool)}
+	for _, line := range strings.Split(idiomData, "\n") {
+		idiom := strings.TrimSpace(line)
+		if idiom == "" || strings.HasPrefix(idiom, "#") {
+			continue
+		}
+		m.idioms[idiom] = true
+		n := len([]rune(idiom))
+		m.maxIdiomLen = max(m.maxIdiomLen, n)
+		if m.minIdiomLen == 0 || n < m.minIdiomLen {
+			m.minIdiomLen = n
+		}
+	}
+	return m
+}
+
+// Analyze 按作文信息服务的响应格式给出分段分句和计数，文体、年级和总分取自请求
+func (m *TextMetrics) Analyze(req *model.EvaluateRequest) *dto_evaluate.APIEssayInfo {
+	info := &dto_evaluate.APIEssayInfo{}
+	if req.Grade != nil {
+		info.Grade = *req.Grade
+	}
+	if req.EssayType != nil {
+		info.EssayType = *req.EssayType
+	}
+	if req.TotalScore != nil {
+		info.AllScore = *req.TotalScore
+	}
+
+	unique := make(map[rune]bool)
+	for _, paragraph := range m.processor.SplitParagraphs(req.Content) {
+		sentences := m.processor.SplitSentences(paragraph)
+		if len(sentences) == 0 {
+			continue
+		}
+		info.Sents = append(info.Sents, sentences)
+		info.Counting.SentNum += len(sentences)
+		for _, r := range paragraph {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			info.Counting.CharNum++
+			if unicode.Is(unicode.Han, r) {
+				unique[r] = true
+			}
+		}
+		info.Counting.IdiomNum += m.countIdioms(paragraph)
+	}
+	info.Counting.ParaNum = len(info.Sents)
+	info.Counting.UniqueWordNum = len(unique)
+	return info
+}
+
+// MarkApproximate 标记作文信息来自本地估算及各计数的可信程度
+func (m *TextMetrics) MarkApproximate(essayInfo *model.EssayInfo) {
+	essayInfo.Source = EssayInfoSourceLocal
+	essayInfo.Approximate = approximateCounts
+	essayInfo.Unavailable = unavailableCounts
+}
+
+// countIdioms 在连续汉字片段中从左到右取最长匹配的成语，匹配后跳过该成语
+func (m *TextMetrics) countIdioms(text string) int {
+	count := 0
+	for _, run := range hanRuns(text) {
+		runes := []rune(run)
+		for i := 0; i < len(runes); {
+			matched := 0
+			for n := min(m.maxIdiomLen, len(runes)-i); n >= m.minIdiomLen && n > 0; n-- {
+				if m.idioms[string(runes[i:i+n])] {
+					matched = n
+					break
+				}
+			}
+			if matched > 0 {
+				count++
+				i += matched
+			} else {
+				i++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,29 @@
+package evaluate
+
+import "testing"
+
+func TestCountIdioms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"没有成语", "今天天气很好。", 0},
+		{"单个成语", "他做事一丝不苟。", 1},
+		{"多个成语", "大家兴高采烈，心花怒放。", 2},
+		{"同一成语重复出现", "一心一意，一心一意。", 2},
+		{"六字成语", "真是百闻不如一见。", 1},
+		{"重叠时从左到右取先匹配的", "一心一意味深长", 1},
+		{"标点隔开的不算", "兴高，采烈", 0},
+		{"夹杂字母数字的不算", "五颜6色", 0},
+	}
+
+	metrics := NewTextMetrics(NewResponseProcessor())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metrics.countIdioms(tt.text); got != tt.want {
+				t.Errorf("countIdioms(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package evaluate
+
+import (
+	"essay-stateless/internal/model"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 本地校对的问题类型，写入 WordEvaluation 的 level2
+const (
+	ProofreadDeDiDe        = "的地得误用"
+	ProofreadQuote         = "引号不成对"
+	ProofreadBracket       = "括号不成对"
+	ProofreadRepeatedPunct = "标点重复"
+	ProofreadHalfWidth     = "半角标点"
+	ProofreadBookTitle     = "书名号使用不当"
+)
+
+// bracketPairs 成对的引号和括号，书名号单独检查
+var bracketPairs = map[rune]rune{'“': '”', '‘': '’', '（': '）', '(': ')', '【': '】', '「': '」', '『': '』'}
+
+// fullWidthPunct 半角标点对应的全角标点，引号按出现次序区分前后
+var fullWidthPunct = map[rune]string{',': "，", '.': "。", ';': "；", ':': "：", '?': "？", '!': "！", '(': "（", ')': "）"}
+
+// pausePunct 不能连用的点号，按改法的优先级排列
+const pausePunct = "。；：，、;:,"
+
+// 的地得规则用到的词表
+var (
+	// adverbialWords 常作状语的词，后接动词时应用“地”
+	adverbialWords = []string{"认真", "仔细", "高兴", "开心", "飞快", "拼命", "努力", "大声", "小声", "小心", "耐心", "用力", "使劲",
+		"兴奋", "激动", "着急", "愉快", "轻声", "悄悄", "默默", "静静", "慢慢", "渐渐", "紧紧", "轻轻", "匆匆", "狠狠", "深深", "不停", "专心", "热情"}
+	// reduplicatedNouns 叠字名词，不按叠字状语处理
+	reduplicatedNouns = "妈爸哥姐弟妹爷奶姥叔伯舅婶公婆宝星娃"
+	// actionVerbs 单字动作动词
+	actionVerbs = "走跑说看笑哭想听喊叫问答站坐跳写读做吃喝望盯打抱拉推飞流点摇拍握摸擦洗扫"
+	// complementVerbs 后接程度补语时应用“得”的动词，不含“说、想”等可构成“的”字短语的动词
+	complementVerbs = "跑走写做唱跳长睡笑哭学读打玩飞游干洗扫"
+	// degreeAdverbs 程度副词
+	degreeAdverbs = "很非常特别十分极真太更格外"
+)
+
+// Proofreader 本地规则校对
+//
+// 检查上游语法服务常漏掉的几类问题：的地得误用、引号括号不成对、点号连用、
+// 中文里夹用半角标点和书名号使用不当。规则都是确定性的，上游不可用时同样生效；
+// 结果以“还需努力”写入好词好句评估结果，与语法检查结果合并去重。
+type Proofreader struct {
+	processor *ResponseProcessor
+}
+
+// NewProofreader 创建本地校对
+func NewProofreader(processor *ResponseProcessor) *Proofreader {
+	return &Proofreader{processor: processor}
+}
+
+// proofreadIssue 段内的一处问题，start/end 为段内字符区间
+type proofreadIssue struct {
+	start, end int
+	kind       string
+	revised    string
+}
+
+// Proofread 校对全文并写入结果，返回新增的问题数
+func (p *Proofreader) Proofread(response *model.EvaluateResponse) int {
+	added := 0
+	for pIndex, paragraph := range response.Text {
+		runes := []rune(strings.Join(paragraph, ""))
+		for _, issue := range proofreadRunes(runes) {
+			sIndex, offset := locateInParagraph(paragraph, issue.start)
+			if sIndex < 0 {
+				continue
+			}
+			length := utf8.RuneCountInString(paragraph[sIndex])
+			wordEval := model.WordEvaluation{
+				Span: []int{offset, min(offset+issue.end-issue.start, length)},
+				Type: map[string]string{
+					"level1": "还需努力",
+					"level2": issue.kind,
+				},
+				Ori:     string(runes[issue.start:issue.end]),
+				Revised: issue.revised,
+			}
+			if p.processor.AddMistake(response, pIndex, sIndex, wordEval) {
+				added++
+			}
+		}
+	}
+	return added
+}
+
+// proofreadRunes 对一段文字依次执行各项检查
+func proofreadRunes(runes []rune) []proofreadIssue {
+	// 成对检查在前：同一位置既不成对又是半角时，只报不成对
+	var issues []proofreadIssue
+	issues = append(issues, checkPairs(runes)...)
+	issues = append(issues, checkBookTitles(runes)...)
+	issues = append(issues, checkDeDiDe(runes)...)
+	issues = append(issues, checkRepeatedPunct(runes)...)
+	issues = append(issues, checkHalfWidth(runes)...)
+	return issues
+}
+
+// checkDeDiDe 状语后接动词用“地”，动词后接程度补语用“得”
+func checkDeDiDe(runes []rune) []proofreadIssue {
+	var issues []proofreadIssue
+	for i := 1; i+1 < len(runes); i++ {
+		r, next := runes[i], runes[i+1]
+		switch {
+		case (r == '的' || r == '得') && strings.ContainsRune(actionVerbs, next) && isAdverbial(runes[:i]):
+			issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: ProofreadDeDiDe, revised: "地"})
+		case (r == '的' || r == '地') && strings.ContainsRune(degreeAdverbs, next) && strings.ContainsRune(complementVerbs, runes[i-1]):
+			issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: ProofreadDeDiDe, revised: "得"})
+		}
+	}
+	return issues
+}
+
+// isAdverbial 前文以常作状语的词或叠字（非叠字名词）结尾
+func isAdverbial(before []rune) bool {
+	text := string(before)
+	for _, word := range adverbialWords {
+		if strings.HasSuffix(text, word) {
+			return true
+		}
+	}
+	n := len(before)
+	if n < 2 || before[n-1] != before[n-2] || !unicode.Is(unicode.Han, before[n-1]) {
+		return false
+	}
+	// “哈哈哈”之类的拟声词不算
+	if n >= 3 && before[n-3] == before[n-1] {
+		return false
+	}
+	return !strings.ContainsRune(reduplicatedNouns, before[n-1])
+}
+
+// checkPairs 段内引号和括号成对，英文双引号按个数奇偶判断
+func checkPairs(runes []rune) []proofreadIssue {
+	var issues []proofreadIssue
+	closers := make(map[rune]rune, len(bracketPairs))
+	for open, closing := range bracketPairs {
+		closers[closing] = open
+	}
+	kindOf := func(r rune) string {
+		if strings.ContainsRune("“”‘’「」『』", r) {
+			return ProofreadQuote
+		}
+		return ProofreadBracket
+	}
+
+	var stack []int
+	lastASCIIQuote, asciiQuotes := -1, 0
+	for i, r := range runes {
+		if _, ok := bracketPairs[r]; ok {
+			stack = append(stack, i)
+			continue
+		}
+		if open, ok := closers[r]; ok {
+			if len(stack) > 0 && runes[stack[len(stack)-1]] == open {
+				stack = stack[:len(stack)-1]
+			} else {
+				issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: kindOf(r)})
+			}
+			continue
+		}
+		if r == '"' {
+			asciiQuotes++
+			lastASCIIQuote = i
+		}
+	}
+	for _, i := range stack {
+		issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: kindOf(runes[i])})
+	}
+	if asciiQuotes%2 == 1 {
+		issues = append(issues, proofreadIssue{start: lastASCIIQuote, end: lastASCIIQuote + 1, kind: ProofreadQuote})
+	}
+	return issues
+}
+
+// checkBookTitles 书名号成对、不为空，书名号内再用书名号时应改用单书名号
+func checkBookTitles(runes []rune) []proofreadIssue {
+	var issues []proofreadIssue
+	open, nested := -1, false
+	for i, r := range runes {
+		switch r {
+		case '《':
+			if open >= 0 {
+				issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: ProofreadBookTitle, revised: "〈"})
+				nested = true
+				continue
+			}
+			open = i
+		case '》':
+			switch {
+			case open < 0:
+				issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: ProofreadBookTitle})
+			case nested:
+				issues = append(issues, proofreadIssue{start: i, end: i + 1, kind: ProofreadBookTitle, revised: "〉"})
+				nested = false
+			case open == i-1:
+				issues = append(issues, proofreadIssue{start: open, end: i + 1, kind: ProofreadBookTitle})
+				open = -1
+			default:
+				open = -1
+			}
+		case '。', '！', '？':
+			// 书名号跨句，视为没有闭合
+			if open >= 0 {
+				issues = append(issues, proofreadIssue{start: open, end: open + 1, kind: ProofreadBookTitle})
+				open, nested = -1, false
+			}
+		}
+	}
+	if open >= 0 {
+		issues = append(issues, proofreadIssue{start: open, end: open + 1, kind: ProofreadBookTitle})
+	}
+	return issues
+}
+
+// checkRepeatedPunct 句号、逗号等点号连用，改为其中优先级最高的一个；叹号、问号和省略号可以连用
+func checkRepeatedPunct(runes []rune) []proofreadIssue {
+	var issues []proofreadIssue
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && strings.ContainsRune(pausePunct, runes[j]) {
+			j++
+		}
+		if j-i >= 2 {
+			issues = append(issues, proofreadIssue{start: i, end: j, kind: ProofreadRepeatedPunct, revised: strongestPunct(runes[i:j])})
+		}
+		i = max(j, i+1)
+	}
+	return issues
+}
+
+func strongestPunct(run []rune) string {
+	best := len(pausePunct)
+	for _, r := range run {
+		best = min(best, strings.IndexRune(pausePunct, r))
+	}
+	r, _ := utf8.DecodeRuneInString(pausePunct[best:])
+	if full, ok := fullWidthPunct[r]; ok {
+		return full
+	}
+	return string(r)
+}
+
+// checkHalfWidth 紧挨汉字的半角标点，数字间的小数点、冒号除外
+func checkHalfWidth(runes []rune) []proofreadIssue {
+	var issues []proofreadIssue
+	asciiQuotes := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if _, ok := fullWidthPunct[r]; !ok && r != '"' {
+			continue
+		}
+		if r == '"' {
+			asciiQuotes++
+		}
+		j := i + 1
+		if r == '.' {
+			for j < len(runes) && runes[j] == '.' {
+				j++
+			}
+		}
+		if !isHan(runes, i-1) && !isHan(runes, j) {
+			continue
+		}
+		if (r == '.' || r == ':') && j == i+1 && isDigit(runes, i-1) && isDigit(runes, j) {
+			continue
+		}
+
+		issue := proofreadIssue{start: i, end: j, kind: ProofreadHalfWidth, revised: fullWidthPunct[r]}
+		switch {
+		case j-i >= 2:
+			issue.revised = "……"
+		case r == '"' && asciiQuotes%2 == 1:
+			issue.revised = "“"
+		case r == '"':
+			issue.revised = "”"
+		}
+		issues = append(issues, issue)
+		i = j - 1
+	}
+	return issues
+}
+
+func isHan(runes []rune, i int) bool {
+	return i >= 0 && i < len(runes) && unicode.Is(unicode.Han, runes[i])
+}
+
+func isDigit(runes []rune, i int) bool {
+	return i >= 0 && i < len(runes) && unicode.IsDigit(runes[i])
+}
+
+// locateInParagraph 段内字符偏移所在的句子及句内偏移
+func locateInParagraph(paragraph []string, offset int) (int, int) {
+	start := 0
+	for s, sentence := range paragraph {
+		length := utf8.RuneCountInString(sentence)
+		if offset < start+length {
+			return s, offset - start
+		}
+		start += length
+	}
+	return -1, 0
+}
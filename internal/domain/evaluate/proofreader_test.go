@@ -0,0 +1,48 @@
+package evaluate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProofreadRunes(t *testing.T) {
+	type found struct {
+		ori, kind, revised string
+	}
+	tests := []struct {
+		name string
+		text string
+		want []found
+	}{
+		{"状语后接动词用地", "他认真的写作业。", []found{{"的", ProofreadDeDiDe, "地"}}},
+		{"叠字状语", "他慢慢的走回家。", []found{{"的", ProofreadDeDiDe, "地"}}},
+		{"动词后接程度补语用得", "他跑的很快。", []found{{"的", ProofreadDeDiDe, "得"}}},
+		{"叠字名词不算状语", "妈妈的笑很温暖。", nil},
+		{"拟声词哈哈哈不算叠字状语", "他哈哈哈的笑了。", nil},
+		{"小数点", "圆周率约为3.14。", nil},
+		{"时间中的冒号", "我们8:30出发。", nil},
+		{"紧挨汉字的半角句号", "我到家了.妈妈在做饭。", []found{{".", ProofreadHalfWidth, "。"}}},
+		{"半角省略号", "我等啊等...他还是没来。", []found{{"...", ProofreadHalfWidth, "……"}}},
+		{"半角引号按次序区分前后", `他说"好"。`, []found{{`"`, ProofreadHalfWidth, "“"}, {`"`, ProofreadHalfWidth, "”"}}},
+		{"引号不成对", "他说：“你好。", []found{{"“", ProofreadQuote, ""}}},
+		{"括号不成对", "今天（星期一下雨。", []found{{"（", ProofreadBracket, ""}}},
+		{"书名号嵌套改用单书名号", "我读了《关于《红楼梦》的随笔》。", []found{{"《", ProofreadBookTitle, "〈"}, {"》", ProofreadBookTitle, "〉"}}},
+		{"空书名号", "他写了《》。", []found{{"《》", ProofreadBookTitle, ""}}},
+		{"书名号跨句未闭合", "我读了《西游记。后来又读了一遍。", []found{{"《", ProofreadBookTitle, ""}}},
+		{"点号连用取优先级最高的", "天黑了，，。", []found{{"，，。", ProofreadRepeatedPunct, "。"}}},
+		{"叹号问号可以连用", "真的吗？！", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runes := []rune(tt.text)
+			var got []found
+			for _, issue := range proofreadRunes(runes) {
+				got = append(got, found{string(runes[issue.start:issue.end]), issue.kind, issue.revised})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("proofreadRunes(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package evaluate
+
+import (
+	"essay-stateless/internal/model"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// coverageThreshold 作文段落覆盖范文某段二字词的比例达到该值时，视为写到了该要点
+	coverageThreshold = 0.2
+	// maxSharedPhrases 最多给出的共有短语数
+	maxSharedPhrases = 12
+	// maxPhraseLen 共有短语的最大长度
+	maxPhraseLen = 16
+	// summaryLen 要点摘要的最大字数
+	summaryLen = 40
+)
+
+// functionChars 虚词，含这些字的二字词不参与相似度和覆盖率计算
+const functionChars = "的了着们地得吗呢吧啊"
+
+// commonChars 常用代词、连词等，两个字都在其中的二字词不参与计算
+const commonChars = "我你他她它们这那是在有和与也都就而又还要会个一不很把被给到说上下里"
+
+// ReferenceComparator 与范文对比
+//
+// 不依赖分词：相似度按二字词频率的余弦相似度计算，要点按段落划分，
+// 作文中最接近的段落覆盖范文该段二字词达到一定比例即视为写到。
+type ReferenceComparator struct{}
+
+// NewReferenceComparator 创建范文对比
+func NewReferenceComparator() *ReferenceComparator {
+	return &ReferenceComparator{}
+}
+
+// Compare 请求未提供范文时返回nil
+func (r *ReferenceComparator) Compare(req *model.EvaluateRequest, response *model.EvaluateResponse) *model.ReferenceComparison {
+	if req.ReferenceEssay == nil {
+		return nil
+	}
+	reference := splitParagraphs(*req.ReferenceEssay)
+	if len(reference) == 0 {
+		return nil
+	}
+	student := make([]string, len(response.Text))
+	for i, paragraph := range response.Text {
+		student[i] = strings.Join(paragraph, "")
+	}
+	if len(student) == 0 {
+		student = splitParagraphs(req.Content)
+	}
+
+	studentText := strings.Join(student, "")
+	comparison := &model.ReferenceComparison{
+		Similarity:    math.Round(cosine(bigramCounts(studentText), bigramCounts(strings.Join(reference, "")))*1000) / 10,
+		Structure:     compareStructure(student, reference),
+		SharedPhrases: sharedPhrases(reference, studentText),
+		Covered:       []model.ReferencePoint{},
+		Missed:        []model.ReferencePoint{},
+	}
+
+	studentBigrams := make([]map[string]int, len(student))
+	for i, paragraph := range student {
+		studentBigrams[i] = bigramCounts(paragraph)
+	}
+	for i, paragraph := range reference {
+		point := model.ReferencePoint{ReferenceParagraphIndex: i, Summary: summarize(paragraph), ParagraphIndex: -1}
+		bigrams := bigramCounts(paragraph)
+		if len(bigrams) == 0 {
+			continue
+		}
+		for j, candidate := range studentBigrams {
+			shared := 0
+			for bigram := range bigrams {
+				if candidate[bigram] > 0 {
+					shared++
+				}
+			}
+			if coverage := float64(shared) / float64(len(bigrams)); coverage > point.Coverage {
+				point.Coverage = coverage
+				point.ParagraphIndex = j
+			}
+		}
+		point.Coverage = math.Round(point.Coverage*100) / 100
+
+		if point.Coverage >= coverageThreshold {
+			for _, phrase := range comparison.SharedPhrases {
+				if strings.Contains(paragraph, phrase) && len(point.KeyPhrases) < 5 {
+					point.KeyPhrases = append(point.KeyPhrases, phrase)
+				}
+			}
+			comparison.Covered = append(comparison.Covered, point)
+		} else {
+			comparison.Missed = append(comparison.Missed, point)
+		}
+	}
+	return comparison
+}
+
+// compareStructure 段落数、篇幅和详写段落位置的差异
+func compareStructure(student, reference []string) model.StructureComparison {
+	structure := model.StructureComparison{
+		ParagraphCount:          len(student),
+		ReferenceParagraphCount: len(reference),
+		ParagraphLengths:        paragraphLengths(student),
+		ReferenceLengths:        paragraphLengths(reference),
+		Differences:             []string{},
+	}
+	for _, n := range structure.ParagraphLengths {
+		structure.CharCount += n
+	}
+	for _, n := range structure.ReferenceLengths {
+		structure.ReferenceCharCount += n
+	}
+
+	if structure.ParagraphCount != structure.ReferenceParagraphCount {
+		structure.Differences = append(structure.Differences,
+			fmt.Sprintf("作文%d段，范文%d段", structure.ParagraphCount, structure.ReferenceParagraphCount))
+	}
+	if structure.ReferenceCharCount > 0 {
+		ratio := float64(structure.CharCount) / float64(structure.ReferenceCharCount)
+		switch {
+		case ratio < 0.7:
+			structure.Differences = append(structure.Differences,
+				fmt.Sprintf("全文%d字，约为范文的%d%%，篇幅偏短", structure.CharCount, int(math.Round(ratio*100))))
+		case ratio > 1.5:
+			structure.Differences = append(structure.Differences,
+				fmt.Sprintf("全文%d字，约为范文的%d%%，篇幅明显长于范文", structure.CharCount, int(math.Round(ratio*100))))
+		}
+	}
+	// 篇幅最长的段落通常是详写部分
+	if len(student) > 1 && len(reference) > 1 {
+		studentLongest, referenceLongest := longest(structure.ParagraphLengths), longest(structure.ReferenceLengths)
+		studentPosition := position(studentLongest, len(student))
+		referencePosition := position(referenceLongest, len(reference))
+		if studentPosition != referencePosition {
+			structure.Differences = append(structure.Differences,
+				fmt.Sprintf("范文详写在%s（第%d段），作文详写在%s（第%d段）", referencePosition, referenceLongest+1, studentPosition, studentLongest+1))
+		}
+	}
+	return structure
+}
+
+// sharedPhrases 范文中同时出现在作文里的短语：从左到右取作文中能找到的最长片段，
+// 按长度优先、在范文中出现的先后排序
+func sharedPhrases(reference []string, studentText string) []string {
+	var phrases []string
+	seen := make(map[string]bool)
+	for _, paragraph := range reference {
+		for _, run := range hanRuns(paragraph) {
+			runes := []rune(run)
+			for start := 0; start+1 < len(runes); {
+				n := 0
+				for end := start + 2; end <= min(len(runes), start+maxPhraseLen); end++ {
+					if !strings.Contains(studentText, string(runes[start:end])) {
+						break
+					}
+					n = end - start
+				}
+				if n == 0 {
+					start++
+					continue
+				}
+				phrase := string(trimFunctionChars(runes[start : start+n]))
+				if utf8.RuneCountInString(phrase) >= 2 && meaningfulPhrase([]rune(phrase)) && !seen[phrase] {
+					seen[phrase] = true
+					phrases = append(phrases, phrase)
+				}
+				start += n
+			}
+		}
+	}
+	sort.SliceStable(phrases, func(i, j int) bool {
+		return utf8.RuneCountInString(phrases[i]) > utf8.RuneCountInString(phrases[j])
+	})
+
+	// 已被更长短语包含的不再列出
+	result := []string{}
+	for _, phrase := range phrases {
+		if len(result) == maxSharedPhrases {
+			break
+		}
+		if !lo.ContainsBy(result, func(kept string) bool { return strings.Contains(kept, phrase) }) {
+			result = append(result, phrase)
+		}
+	}
+	return result
+}
+
+// trimFunctionChars 去掉首尾的虚词
+func trimFunctionChars(runes []rune) []rune {
+	for len(runes) > 0 && strings.ContainsRune(functionChars, runes[0]) {
+		runes = runes[1:]
+	}
+	for len(runes) > 0 && strings.ContainsRune(functionChars, runes[len(runes)-1]) {
+		runes = runes[:len(runes)-1]
+	}
+	return runes
+}
+
+// meaningfulPhrase 首尾不是虚词，且不全由常用字组成
+func meaningfulPhrase(runes []rune) bool {
+	if strings.ContainsRune(functionChars, runes[0]) || strings.ContainsRune(functionChars, runes[len(runes)-1]) {
+		return false
+	}
+	for _, r := range runes {
+		if !strings.ContainsRune(commonChars, r) && !strings.ContainsRune(functionChars, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// bigramCounts 汉字二字词频率，跳过含虚词或全由常用字组成的二字词
+func bigramCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, run := range hanRuns(text) {
+		runes := []rune(run)
+		for i := 0; i+1 < len(runes); i++ {
+			if pair := runes[i : i+2]; meaningfulPhrase(pair) {
+				counts[string(pair)]++
+			}
+		}
+	}
+	return counts
+}
+
+func cosine(a, b map[string]int) float64 {
+	var dot, normA, normB float64
+	for k, v := range a {
+		normA += float64(v * v)
+		dot += float64(v * b[k])
+	}
+	for _, v := range b {
+		normB += float64(v * v)
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// hanRuns 连续的汉字片段
+func hanRuns(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.Is(unicode.Han, r) })
+}
+
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return paragraphs
+}
+
+func paragraphLengths(paragraphs []string) []int {
+	lengths := make([]int, len(paragraphs))
+	for i, paragraph := range paragraphs {
+		lengths[i] = countChars(paragraph)
+	}
+	return lengths
+}
+
+func longest(lengths []int) int {
+	index := 0
+	for i, n := range lengths {
+		if n > lengths[index] {
+			index = i
+		}
+	}
+	return index
+}
+
+func position(index, count int) string {
+	switch index {
+	case 0:
+		return "开头"
+	case count - 1:
+		return "结尾"
+	default:
+		return "中间"
+	}
+}
+
+// summarize 段落首句，过长时截断
+func summarize(paragraph string) string {
+	sentence := paragraph
+	if i := strings.IndexAny(paragraph, "。！？!?"); i >= 0 {
+		_, size := utf8.DecodeRuneInString(paragraph[i:])
+		sentence = paragraph[:i+size]
+	}
+	if runes := []rune(sentence); len(runes) > summaryLen {
+		return string(runes[:summaryLen]) + "…"
+	}
+	return sentence
+}
@@ -4,6 +4,8 @@ import (
 	dto_evaluate "essay-stateless/internal/dto/evaluate"
 	"essay-stateless/internal/model"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -32,18 +34,8 @@ func (p *ResponseProcessor) ProcessWordSentence(wordSentence *dto_evaluate.APIWo
 		return
 	}
 
-	sentencesEvaluations := make([][]model.SentenceEvaluation, len(response.Text))
-	for i, paragraph := range response.Text {
-		sentencesEvaluations[i] = make([]model.SentenceEvaluation, len(paragraph))
-		for j := range paragraph {
-			sentencesEvaluations[i][j] = model.SentenceEvaluation{
-				IsGoodSentence:  false,
-				Label:           "",
-				Type:            make(map[string]string),
-				WordEvaluations: []model.WordEvaluation{},
-			}
-		}
-	}
+	// 语法检查和本地校对可能先完成，在已有结果上写入，不能重建
+	sentencesEvaluations := p.sentenceEvaluations(response)
 
 	// 处理好句
 	for _, sent := range wordSentence.Data.Results.GoodSents {
@@ -71,7 +63,6 @@ func (p *ResponseProcessor) ProcessWordSentence(wordSentence *dto_evaluate.APIWo
 		}
 	}
 
-	response.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations = sentencesEvaluations
 	response.AIEvaluation.WordSentenceEvaluation.WordSentenceScore = wordSentence.Score
 }
 
@@ -96,10 +87,46 @@ func (p *ResponseProcessor) ProcessGrammar(grammarInfo *dto_evaluate.APIGrammarI
 			Ori:     typo.Ori,
 			Revised: typo.Revised,
 		}
+		p.AddMistake(response, gp.ParagraphIndex, gp.SentenceIndex, wordEval)
+	}
+}
 
-		response.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations[gp.ParagraphIndex][gp.SentenceIndex].WordEvaluations = append(
-			response.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations[gp.ParagraphIndex][gp.SentenceIndex].WordEvaluations, wordEval)
+// AddMistake 向句子写入一条“还需努力”的词，与已有的问题重复时忽略
+//
+// 同一位置的问题视为重复；位置重叠且改法相同的也视为重复（语法检查与本地校对常以不同区间标出同一处）
+func (p *ResponseProcessor) AddMistake(response *model.EvaluateResponse, paragraphIndex, sentenceIndex int, wordEval model.WordEvaluation) bool {
+	sentencesEvaluations := p.sentenceEvaluations(response)
+	if paragraphIndex >= len(sentencesEvaluations) || sentenceIndex >= len(sentencesEvaluations[paragraphIndex]) {
+		return false
+	}
+	sentence := &sentencesEvaluations[paragraphIndex][sentenceIndex]
+	for _, existing := range sentence.WordEvaluations {
+		if existing.Type["level1"] != "还需努力" || len(existing.Span) != 2 {
+			continue
+		}
+		sameSpan := existing.Span[0] == wordEval.Span[0] && existing.Span[1] == wordEval.Span[1]
+		overlap := existing.Span[0] < wordEval.Span[1] && wordEval.Span[0] < existing.Span[1]
+		if sameSpan || overlap && existing.Revised != "" && existing.Revised == wordEval.Revised {
+			return false
+		}
 	}
+	sentence.WordEvaluations = append(sentence.WordEvaluations, wordEval)
+	return true
+}
+
+// sentenceEvaluations 返回与分句一致的好词好句评估结果，未初始化或与分句不一致时重新初始化
+func (p *ResponseProcessor) sentenceEvaluations(response *model.EvaluateResponse) [][]model.SentenceEvaluation {
+	wordSentenceEvaluation := &response.AIEvaluation.WordSentenceEvaluation
+	if len(wordSentenceEvaluation.SentenceEvaluations) != len(response.Text) {
+		wordSentenceEvaluation.SentenceEvaluations = newSentenceEvaluations(response.Text)
+	}
+	for i, paragraph := range response.Text {
+		if len(wordSentenceEvaluation.SentenceEvaluations[i]) != len(paragraph) {
+			wordSentenceEvaluation.SentenceEvaluations = newSentenceEvaluations(response.Text)
+			break
+		}
+	}
+	return wordSentenceEvaluation.SentenceEvaluations
 }
 
 // ProcessOverall 处理总体评价响应
@@ -143,43 +170,54 @@ func (p *ResponseProcessor) ProcessScore(score *model.APIScore, req *model.Evalu
 
 	// 设置评论
 	response.AIEvaluation.ScoreEvaluation.Comment = score.Result.Comment
-	response.AIEvaluation.ScoreEvaluation.Comments.Appearance = score.Result.Comments.Appearance
 	response.AIEvaluation.ScoreEvaluation.Comments.Content = score.Result.Comments.Content
 	response.AIEvaluation.ScoreEvaluation.Comments.Expression = score.Result.Comments.Expression
 	response.AIEvaluation.ScoreEvaluation.Comments.Structure = score.Result.Comments.Structure
 	response.AIEvaluation.ScoreEvaluation.Comments.Development = score.Result.Comments.Development
+	if req.HasImages() {
+		response.AIEvaluation.ScoreEvaluation.Comments.Appearance = score.Result.Comments.Appearance
+	}
 
 	// 设置原始分数
 	response.AIEvaluation.ScoreEvaluation.Scores.All = score.Result.Scores.All
-	response.AIEvaluation.ScoreEvaluation.Scores.Appearance = score.Result.Scores.Appearance
 	response.AIEvaluation.ScoreEvaluation.Scores.Content = score.Result.Scores.Content
 	response.AIEvaluation.ScoreEvaluation.Scores.Expression = score.Result.Scores.Expression
 	response.AIEvaluation.ScoreEvaluation.Scores.Structure = score.Result.Scores.Structure
 	response.AIEvaluation.ScoreEvaluation.Scores.Development = score.Result.Scores.Development
+	// 没有原稿图片时上游无从评价卷面，忽略其卷面分和卷面评语
+	if req.HasImages() {
+		response.AIEvaluation.ScoreEvaluation.Scores.Appearance = score.Result.Scores.Appearance
+	}
+
+	p.FormatScoreTotals(req, response)
+}
+
+// FormatScoreTotals 按当前分数生成“分数/满分”文本，分数被校正后需重新生成
+func (p *ResponseProcessor) FormatScoreTotals(req *model.EvaluateRequest, response *model.EvaluateResponse) {
+	scores := &response.AIEvaluation.ScoreEvaluation.Scores
 
-	// 计算总分比例（直接用上游分数和总分）
-	response.AIEvaluation.ScoreEvaluation.Scores.AllWithTotal = fmt.Sprintf("%d/%d",
-		score.Result.Scores.All, response.EssayInfo.AllScore)
+	// 计算总分比例
+	scores.AllWithTotal = fmt.Sprintf("%d/%d", scores.All, response.EssayInfo.AllScore)
 
 	// 计算各项分数比例（使用 req 中的分项总分作为分母）
 	if req.ContentScore != nil && *req.ContentScore > 0 {
-		response.AIEvaluation.ScoreEvaluation.Scores.ContentWithTotal = fmt.Sprintf("%d/%d",
-			score.Result.Scores.Content, *req.ContentScore)
+		scores.ContentWithTotal = fmt.Sprintf("%d/%d", scores.Content, *req.ContentScore)
 	}
 
 	if req.ExpressionScore != nil && *req.ExpressionScore > 0 {
-		response.AIEvaluation.ScoreEvaluation.Scores.ExpressionWithTotal = fmt.Sprintf("%d/%d",
-			score.Result.Scores.Expression, *req.ExpressionScore)
+		scores.ExpressionWithTotal = fmt.Sprintf("%d/%d", scores.Expression, *req.ExpressionScore)
 	}
 
 	if req.StructureScore != nil && *req.StructureScore > 0 {
-		response.AIEvaluation.ScoreEvaluation.Scores.StructureWithTotal = fmt.Sprintf("%d/%d",
-			score.Result.Scores.Structure, *req.StructureScore)
+		scores.StructureWithTotal = fmt.Sprintf("%d/%d", scores.Structure, *req.StructureScore)
 	}
 
 	if req.DevelopmentScore != nil && *req.DevelopmentScore > 0 {
-		response.AIEvaluation.ScoreEvaluation.Scores.DevelopmentWithTotal = fmt.Sprintf("%d/%d",
-			score.Result.Scores.Development, *req.DevelopmentScore)
+		scores.DevelopmentWithTotal = fmt.Sprintf("%d/%d", scores.Development, *req.DevelopmentScore)
+	}
+
+	if req.HasImages() && req.AppearanceScore != nil && *req.AppearanceScore > 0 {
+		scores.AppearanceWithTotal = fmt.Sprintf("%d/%d", scores.Appearance, *req.AppearanceScore)
 	}
 }
 
@@ -302,8 +340,12 @@ func (p *ResponseProcessor) InitializeResponse(response *model.EvaluateResponse,
 	}
 
 	// 初始化好词好句评估结果
-	sentencesEvaluations := make([][]model.SentenceEvaluation, len(response.Text))
-	for i, paragraph := range response.Text {
+	response.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations = newSentenceEvaluations(response.Text)
+}
+
+func newSentenceEvaluations(text [][]string) [][]model.SentenceEvaluation {
+	sentencesEvaluations := make([][]model.SentenceEvaluation, len(text))
+	for i, paragraph := range text {
 		sentencesEvaluations[i] = make([]model.SentenceEvaluation, len(paragraph))
 		for j := range paragraph {
 			sentencesEvaluations[i][j] = model.SentenceEvaluation{
@@ -314,7 +356,27 @@ func (p *ResponseProcessor) InitializeResponse(response *model.EvaluateResponse,
 			}
 		}
 	}
-	response.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations = sentencesEvaluations
+	return sentencesEvaluations
+}
+
+// cloneWordSentenceEvaluation 复制好词好句评估结果用于进度消息
+//
+// 词句评估、语法检查的结果写在同一结构上，进度消息编码时另一步骤可能仍在写入，须复制句子的类型和词列表；
+// 已写入的词不再修改，词本身不复制
+func cloneWordSentenceEvaluation(in model.WordSentenceEvaluation) model.WordSentenceEvaluation {
+	out := model.WordSentenceEvaluation{
+		SentenceEvaluations: make([][]model.SentenceEvaluation, len(in.SentenceEvaluations)),
+		WordSentenceScore:   in.WordSentenceScore,
+	}
+	for i, paragraph := range in.SentenceEvaluations {
+		out.SentenceEvaluations[i] = make([]model.SentenceEvaluation, len(paragraph))
+		for j, sentence := range paragraph {
+			sentence.Type = maps.Clone(sentence.Type)
+			sentence.WordEvaluations = slices.Clone(sentence.WordEvaluations)
+			out.SentenceEvaluations[i][j] = sentence
+		}
+	}
+	return out
 }
 
 // ConvertToMap 将请求转换为map格式（用于API调用）
@@ -342,6 +404,11 @@ func (p *ResponseProcessor) SplitParagraphs(content string) []string {
 	return result
 }
 
+const (
+	sentenceEnders = "。！？.!?…"
+	closingQuotes  = "”’」』）)"
+)
+
 // SplitSentences 将段落分割为句子
 func (p *ResponseProcessor) SplitSentences(paragraph string) []string {
 	// 简单的句子分割逻辑，可以根据需要改进
@@ -350,17 +417,22 @@ func (p *ResponseProcessor) SplitSentences(paragraph string) []string {
 		return []string{}
 	}
 
-	// 按句号、问号、感叹号分割
+	// 按句号、问号、感叹号、省略号分割，紧跟的结束标点和后引号归入前一句
 	var sentences []string
 	var current strings.Builder
+	ended := false
 
 	for _, r := range paragraph {
-		current.WriteRune(r)
-		if r == '。' || r == '！' || r == '？' || r == '.' || r == '!' || r == '?' {
+		if ended && !strings.ContainsRune(sentenceEnders+closingQuotes, r) {
 			if s := strings.TrimSpace(current.String()); s != "" {
 				sentences = append(sentences, s)
 			}
 			current.Reset()
+			ended = false
+		}
+		current.WriteRune(r)
+		if strings.ContainsRune(sentenceEnders, r) {
+			ended = true
 		}
 	}
 
@@ -0,0 +1,32 @@
+package evaluate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name      string
+		paragraph string
+		want      []string
+	}{
+		{"空段落", "  ", []string{}},
+		{"句号问号叹号", "今天下雨了。你带伞了吗？快回家！", []string{"今天下雨了。", "你带伞了吗？", "快回家！"}},
+		{"没有结束标点", "今天下雨了", []string{"今天下雨了"}},
+		{"连续的结束标点归入前一句", "真的吗？！我不信。", []string{"真的吗？！", "我不信。"}},
+		{"后引号归入前一句", "他说：“我来了。”然后坐下。", []string{"他说：“我来了。”", "然后坐下。"}},
+		{"省略号后的后引号", "她小声说：“我再想想……”说完就走了。", []string{"她小声说：“我再想想……”", "说完就走了。"}},
+		{"省略号后的后括号和后引号", "“等一等（我马上来……）”他喊道。", []string{"“等一等（我马上来……）”", "他喊道。"}},
+		{"句中的前引号不切分", "“走吧。”“好。”", []string{"“走吧。”", "“好。”"}},
+	}
+
+	processor := NewResponseProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := processor.SplitSentences(tt.paragraph); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitSentences(%q) = %q, want %q", tt.paragraph, got, tt.want)
+			}
+		})
+	}
+}
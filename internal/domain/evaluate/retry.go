@@ -2,6 +2,7 @@ package evaluate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -36,8 +37,8 @@ func NewRetryExecutor(config RetryConfig) *RetryExecutor {
 	}
 }
 
-// Execute 执行带重试的函数
-func (r *RetryExecutor) Execute(ctx context.Context, fn func() error, stepName string) error {
+// Execute 执行带重试的函数，fn收到的context带有本次尝试的序号（从1开始），审计记录据此标注重试
+func (r *RetryExecutor) Execute(ctx context.Context, fn func(ctx context.Context) error, stepName string) error {
 	var lastErr error
 	delay := r.config.InitialDelay
 
@@ -50,9 +51,15 @@ func (r *RetryExecutor) Execute(ctx context.Context, fn func() error, stepName s
 		}
 
 		// 执行函数
-		if err := fn(); err != nil {
+		if err := fn(withAttempt(ctx, i+1)); err != nil {
 			lastErr = err
 
+			// 明确不可重试的错误（如上游响应缺少必要字段、4xx）直接返回
+			if !isRetryable(err) {
+				markAttempts(err, i+1)
+				return err
+			}
+
 			if i < r.config.MaxRetries {
 				logrus.WithFields(logrus.Fields{
 					"step":    stepName,
@@ -79,5 +86,23 @@ func (r *RetryExecutor) Execute(ctx context.Context, fn func() error, stepName s
 		}
 	}
 
+	markAttempts(lastErr, r.config.MaxRetries+1)
 	return fmt.Errorf("%s 失败，已重试 %d 次: %w", stepName, r.config.MaxRetries, lastErr)
 }
+
+// isRetryable 错误链中带有Retryable()判断时以其为准，否则默认可重试
+func isRetryable(err error) bool {
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	return true
+}
+
+// markAttempts 在步骤错误上记录实际尝试次数
+func markAttempts(err error, attempts int) {
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		stepErr.Attempts = attempts
+	}
+}
@@ -0,0 +1,282 @@
+package evaluate
+
+import (
+	"context"
+	"encoding/json"
+	"essay-stateless/internal/config"
+	dto_evaluate "essay-stateless/internal/dto/evaluate"
+	"essay-stateless/internal/model"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShadowStore 影子对比记录存储
+type ShadowStore interface {
+	Save(ctx context.Context, evaluation *model.ShadowEvaluation) error
+}
+
+// ShadowRunner 影子流量：按采样比例把选定步骤同时发往候选上游
+//
+// 影子调用与主流程完全解耦：使用独立的context（不随用户断开取消）、不重试、不写入用户的流，
+// 主流程只在步骤完成时登记结果，最终对比和落库在后台进行。
+type ShadowRunner struct {
+	name          string
+	samplePercent float64
+	timeout       time.Duration
+	clients       *APIClientsFactory
+	steps         []string
+	store         ShadowStore
+}
+
+// NewShadowRunner 创建影子流量执行器，未启用或未配置任何步骤时返回nil
+func NewShadowRunner(cfg config.ShadowConfig, store ShadowStore) *ShadowRunner {
+	if !cfg.Enabled || cfg.SamplePercent <= 0 || store == nil {
+		return nil
+	}
+
+	urls := map[string]string{
+		"word_sentence": cfg.API.WordSentence,
+		"grammar":       cfg.API.GrammarInfo,
+		"overall":       cfg.API.Overall,
+		"suggestion":    cfg.API.Suggestion,
+		"paragraph":     cfg.API.Paragraph,
+		"score":         cfg.API.Score,
+		"polishing":     cfg.API.Polishing,
+	}
+	var steps []string
+	for _, step := range parallelSteps {
+		if urls[step] != "" {
+			steps = append(steps, step)
+		}
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	logrus.Infof("影子流量已启用: %s, 采样 %.1f%%, 步骤 %v", cfg.Name, cfg.SamplePercent, steps)
+	return &ShadowRunner{
+		name:          cfg.Name,
+		samplePercent: cfg.SamplePercent,
+		timeout:       timeout,
+		clients:       NewAPIClientsFactory(&cfg.API),
+		steps:         steps,
+		store:         store,
+	}
+}
+
+// Sample 按采样比例决定本次请求是否走影子流量，未命中返回nil
+func (r *ShadowRunner) Sample() *ShadowRun {
+	if r == nil || rand.Float64()*100 >= r.samplePercent {
+		return nil
+	}
+	return &ShadowRun{
+		runner:  r,
+		primary: make(map[string]*APIResult),
+		shadow:  make(map[string]*APIResult),
+	}
+}
+
+// ShadowRun 一次被采样请求的影子调用，所有方法对nil接收者安全
+type ShadowRun struct {
+	runner *ShadowRunner
+	ctx    context.Context
+	cancel context.CancelFunc
+	req    *model.EvaluateRequest
+	// scoringReq 与主流程评分相同的请求（含按文体权重分配的各维度满分），保证分数差异按同一口径比较
+	scoringReq *model.EvaluateRequest
+	traceID    string
+
+	// primary 只在协调器的聚合循环中写入，Finish之后才在后台读取
+	primary map[string]*APIResult
+
+	mu     sync.Mutex
+	shadow map[string]*APIResult
+	wg     sync.WaitGroup
+}
+
+// Start 在后台并行调用候选上游，req为客户端原始请求（写入对比记录），scoringReq为主流程评分使用的请求
+func (run *ShadowRun) Start(ctx context.Context, req, scoringReq *model.EvaluateRequest, essay map[string]any) {
+	if run == nil {
+		return
+	}
+
+	// 保留trace信息但不随用户请求取消，候选上游的调用不计入本次批改的审计记录
+	run.ctx, run.cancel = context.WithTimeout(withoutAudit(context.WithoutCancel(ctx)), run.runner.timeout)
+	run.req, run.scoringReq = req, scoringReq
+	if spanContext := trace.SpanFromContext(ctx).SpanContext(); spanContext.HasTraceID() {
+		run.traceID = spanContext.TraceID().String()
+	}
+
+	for _, step := range run.runner.steps {
+		run.wg.Add(1)
+		go run.call(step, essay)
+	}
+}
+
+// RecordPrimary 登记主上游的步骤结果
+func (run *ShadowRun) RecordPrimary(result *APIResult) {
+	if run == nil {
+		return
+	}
+	run.primary[result.Step] = result
+}
+
+// Finish 主流程结束后调用，等待影子调用完成后在后台对比并落库
+func (run *ShadowRun) Finish() {
+	if run == nil || run.ctx == nil {
+		return
+	}
+
+	go func() {
+		defer run.cancel()
+		defer func() {
+			if r := recover(); r != nil {
+				logrus.WithField("panic", r).Error("Panic in shadow evaluation")
+			}
+		}()
+
+		run.wg.Wait()
+		evaluation := run.buildEvaluation()
+		if err := run.runner.store.Save(run.ctx, evaluation); err != nil {
+			logrus.Errorf("保存影子对比记录失败: %v", err)
+			return
+		}
+		logrus.Infof("影子对比完成 [%s] trace=%s, 分数差异 %d 项", run.runner.name, run.traceID, len(evaluation.ScoreDeltas))
+	}()
+}
+
+// call 调用候选上游的单个步骤（不重试）
+func (run *ShadowRun) call(step string, essay map[string]any) {
+	defer run.wg.Done()
+
+	result := &APIResult{Step: step}
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("panic: %v", r)
+		}
+		run.mu.Lock()
+		run.shadow[step] = result
+		run.mu.Unlock()
+	}()
+
+	start := time.Now()
+	clients := run.runner.clients
+	switch step {
+	case "word_sentence":
+		result.Data, result.Err = clients.CreateWordSentenceClient().Evaluate(run.ctx, essay)
+	case "grammar":
+		result.Data, result.Err = clients.CreateGrammarClient().Check(run.ctx, essay)
+	case "overall":
+		result.Data, result.Err = clients.CreateOverallClient().Evaluate(run.ctx, essay)
+	case "suggestion":
+		result.Data, result.Err = clients.CreateSuggestionClient().Generate(run.ctx, essay)
+	case "paragraph":
+		result.Data, result.Err = clients.CreateParagraphClient().Evaluate(run.ctx, essay)
+	case "score":
+		result.Data, result.Err = clients.CreateScoreClient().Calculate(run.ctx, essay, run.scoringReq)
+	case "polishing":
+		result.Data, result.Err = run.polish(essay)
+	}
+	result.Elapsed = time.Since(start)
+}
+
+// polish 收集候选上游的全部润色段落
+func (run *ShadowRun) polish(essay map[string]any) ([]model.APIPolishingContent, error) {
+	streamChan := make(chan string, 10)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(streamChan)
+		errCh <- run.runner.clients.CreatePolishingClient().PolishStream(run.ctx, essay, streamChan)
+	}()
+
+	chunks := []model.APIPolishingContent{}
+	for content := range streamChan {
+		var polishing model.APIPolishingContent
+		if err := json.Unmarshal([]byte(content), &polishing); err == nil {
+			chunks = append(chunks, polishing)
+		}
+	}
+	return chunks, <-errCh
+}
+
+// buildEvaluation 组装主/候选结果并计算分数差异
+func (run *ShadowRun) buildEvaluation() *model.ShadowEvaluation {
+	evaluation := &model.ShadowEvaluation{
+		TraceID:    run.traceID,
+		Candidate:  run.runner.name,
+		Request:    run.req,
+		CreateTime: time.Now(),
+	}
+
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	for _, step := range run.runner.steps {
+		stepResult := model.ShadowStepResult{Step: step}
+		if primary := run.primary[step]; primary != nil {
+			stepResult.Primary = primary.Data
+			stepResult.PrimaryLatencyMs = primary.Elapsed.Milliseconds()
+			if primary.Err != nil {
+				stepResult.PrimaryError = primary.Err.Error()
+			}
+		}
+		if shadow := run.shadow[step]; shadow != nil {
+			stepResult.Shadow = shadow.Data
+			stepResult.ShadowLatencyMs = shadow.Elapsed.Milliseconds()
+			if shadow.Err != nil {
+				stepResult.ShadowError = shadow.Err.Error()
+			}
+		}
+		evaluation.Steps = append(evaluation.Steps, stepResult)
+	}
+
+	evaluation.ScoreDeltas = run.scoreDeltas()
+	return evaluation
+}
+
+// scoreDeltas 两侧都成功的步骤才比较分数
+func (run *ShadowRun) scoreDeltas() []model.ShadowScoreDelta {
+	var deltas []model.ShadowScoreDelta
+	add := func(name string, primary, shadow int64) {
+		deltas = append(deltas, model.ShadowScoreDelta{Name: name, Primary: primary, Shadow: shadow, Delta: shadow - primary})
+	}
+
+	if p, s, ok := bothData[*model.APIScore](run.primary["score"], run.shadow["score"]); ok {
+		add("all", p.Result.Scores.All, s.Result.Scores.All)
+		add("content", p.Result.Scores.Content, s.Result.Scores.Content)
+		add("expression", p.Result.Scores.Expression, s.Result.Scores.Expression)
+		add("structure", p.Result.Scores.Structure, s.Result.Scores.Structure)
+		add("development", p.Result.Scores.Development, s.Result.Scores.Development)
+		add("appearance", p.Result.Scores.Appearance, s.Result.Scores.Appearance)
+	}
+	if p, s, ok := bothData[*dto_evaluate.APIOverall](run.primary["overall"], run.shadow["overall"]); ok {
+		add("topicRelevance", int64(p.Score), int64(s.Score))
+	}
+	if p, s, ok := bothData[*dto_evaluate.APIWordSentence](run.primary["word_sentence"], run.shadow["word_sentence"]); ok {
+		add("wordSentence", int64(p.Score), int64(s.Score))
+	}
+	return deltas
+}
+
+func bothData[T any](primary, shadow *APIResult) (T, T, bool) {
+	var zero T
+	if primary == nil || shadow == nil || primary.Err != nil || shadow.Err != nil {
+		return zero, zero, false
+	}
+	p, ok1 := primary.Data.(T)
+	s, ok2 := shadow.Data.(T)
+	if !ok1 || !ok2 {
+		return zero, zero, false
+	}
+	return p, s, true
+}
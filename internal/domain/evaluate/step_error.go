@@ -0,0 +1,152 @@
+package evaluate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"essay-stateless/pkg/httpclient"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// StepErrorKind 步骤错误类型
+type StepErrorKind string
+
+const (
+	// StepErrorTransport 网络错误、非200状态码或响应无法解析
+	StepErrorTransport StepErrorKind = "transport"
+	// StepErrorBusiness 上游返回200但业务code非0
+	StepErrorBusiness StepErrorKind = "business"
+	// StepErrorInvalid 业务code正常但缺少必要字段
+	StepErrorInvalid StepErrorKind = "invalid"
+	// StepErrorCanceled 请求被取消或超时
+	StepErrorCanceled StepErrorKind = "canceled"
+)
+
+// StepError 上游步骤错误，重试策略据此判断是否值得重试
+type StepError struct {
+	Step       string
+	Kind       StepErrorKind
+	Code       string // 上游业务code或HTTP状态码
+	Message    string
+	StatusCode int
+	Attempts   int // 实际尝试次数，由重试执行器填写
+	Err        error
+}
+
+func (e *StepError) Error() string {
+	switch e.Kind {
+	case StepErrorBusiness:
+		if e.Code == "" {
+			return fmt.Sprintf("上游业务错误: %s", e.Message)
+		}
+		return fmt.Sprintf("上游业务错误 code=%s: %s", e.Code, e.Message)
+	case StepErrorInvalid:
+		return fmt.Sprintf("上游响应无效: %s", e.Message)
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable 是否值得重试
+//
+// 业务错误多为上游模型超时、限流，重试通常能恢复；缺少必要字段时同样的输入大概率得到同样的结果，
+// 重试只会拖慢整体进度；4xx（408、429除外）说明请求本身有问题，不重试。
+func (e *StepError) Retryable() bool {
+	switch e.Kind {
+	case StepErrorBusiness:
+		return true
+	case StepErrorInvalid, StepErrorCanceled:
+		return false
+	}
+	if e.StatusCode >= 400 && e.StatusCode < 500 {
+		return e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// newTransportError 包装HTTP调用错误
+func newTransportError(step string, err error) *StepError {
+	stepErr := &StepError{Step: step, Kind: StepErrorTransport, Err: err}
+
+	var statusErr *httpclient.StatusError
+	var eventErr *httpclient.StreamEventError
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		stepErr.Kind = StepErrorCanceled
+	case errors.As(err, &statusErr):
+		stepErr.StatusCode = statusErr.StatusCode
+		stepErr.Code = strconv.Itoa(statusErr.StatusCode)
+	case errors.As(err, &eventErr):
+		// 流式响应中途的错误事件视为业务错误
+		stepErr.Kind = StepErrorBusiness
+		stepErr.Message = eventErr.Event
+	}
+	return stepErr
+}
+
+// checkCode 校验字符串业务code，"0"为成功；未返回code时不做判断
+func checkCode(step, code, message string) error {
+	if code == "" || code == "0" {
+		return nil
+	}
+	return &StepError{Step: step, Kind: StepErrorBusiness, Code: code, Message: message}
+}
+
+// checkIntCode 校验数值业务code，0为成功
+func checkIntCode(step string, code int, message string) error {
+	if code == 0 {
+		return nil
+	}
+	return &StepError{Step: step, Kind: StepErrorBusiness, Code: strconv.Itoa(code), Message: message}
+}
+
+// requireField 必要字段为空时返回无效响应错误
+func requireField(step, field string, present bool) error {
+	if present {
+		return nil
+	}
+	return &StepError{Step: step, Kind: StepErrorInvalid, Message: "缺少字段 " + field}
+}
+
+// fieldPresent 响应JSON中按路径逐层取的字段是否存在且不为null，用于数值字段（零值不能代表缺失）
+func fieldPresent(raw json.RawMessage, path ...string) bool {
+	for _, key := range path {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return false
+		}
+		value, ok := object[key]
+		if !ok || string(value) == "null" {
+			return false
+		}
+		raw = value
+	}
+	return true
+}
+
+// firstError 返回第一个非nil错误：业务code异常时不再报告随之缺失的字段
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsStepError 从错误链中取出步骤错误，未分类的错误按网络错误处理
+func AsStepError(step string, err error) *StepError {
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		return stepErr
+	}
+	return newTransportError(step, err)
+}
@@ -3,32 +3,80 @@ package evaluate
 import (
 	"context"
 	"encoding/json"
+	"essay-stateless/internal/domain/genre"
+	"essay-stateless/internal/domain/rubric"
 	dto_evaluate "essay-stateless/internal/dto/evaluate"
 	"essay-stateless/internal/model"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
 // APIResult API调用结果
 type APIResult struct {
-	Step string // API步骤名
-	Data any    // API返回数据
-	Err  error  // 错误信息
+	Step    string        // API步骤名
+	Data    any           // API返回数据
+	Err     error         // 错误信息
+	Elapsed time.Duration // 耗时（含重试）
+}
+
+// parallelSteps essay_info之后并行执行的步骤
+var parallelSteps = []string{"word_sentence", "grammar", "overall", "suggestion", "paragraph", "score", "polishing"}
+
+// ParallelSteps essay_info之后并行执行的步骤，供文体配置校验步骤名
+func ParallelSteps() []string {
+	return slices.Clone(parallelSteps)
 }
 
 // StreamCoordinator 流式处理协调器
 type StreamCoordinator struct {
 	retryExecutor     *RetryExecutor
 	responseProcessor *ResponseProcessor
+	latencyTracker    *LatencyTracker
+	shadowRunner      *ShadowRunner
+	bandClassifier    *rubric.BandClassifier
+	scoreNormalizer   *rubric.ScoreNormalizer
+	offTopicGate      *rubric.OffTopicGate
+	ensembleScorer    *EnsembleScorer
+	genreProfiles     *genre.Profiles
+	evidenceLinker    *EvidenceLinker
+	compliance        *ComplianceAnalyzer
+	reference         *ReferenceComparator
+	textMetrics       *TextMetrics
+	proofreader       *Proofreader
 }
 
-// NewStreamCoordinator 创建流式协调器
-func NewStreamCoordinator() *StreamCoordinator {
+// NewStreamCoordinator 创建流式协调器，shadowRunner为nil时不发送影子流量，bandClassifier为nil时不做考试标准分类，
+// scoreNormalizer为nil时不校正上游分数，offTopicGate为nil时不做偏题限分，ensembleScorer为nil时评分只调用一次，
+// genreProfiles为nil时所有文体按默认流程批改
+func NewStreamCoordinator(
+	latencyTracker *LatencyTracker,
+	shadowRunner *ShadowRunner,
+	bandClassifier *rubric.BandClassifier,
+	scoreNormalizer *rubric.ScoreNormalizer,
+	offTopicGate *rubric.OffTopicGate,
+	ensembleScorer *EnsembleScorer,
+	genreProfiles *genre.Profiles,
+) *StreamCoordinator {
+	responseProcessor := NewResponseProcessor()
 	return &StreamCoordinator{
 		retryExecutor:     NewRetryExecutor(DefaultRetryConfig()),
-		responseProcessor: NewResponseProcessor(),
+		responseProcessor: responseProcessor,
+		latencyTracker:    latencyTracker,
+		shadowRunner:      shadowRunner,
+		bandClassifier:    bandClassifier,
+		scoreNormalizer:   scoreNormalizer,
+		offTopicGate:      offTopicGate,
+		ensembleScorer:    ensembleScorer,
+		genreProfiles:     genreProfiles,
+		evidenceLinker:    NewEvidenceLinker(),
+		compliance:        NewComplianceAnalyzer(),
+		reference:         NewReferenceComparator(),
+		textMetrics:       NewTextMetrics(responseProcessor),
+		proofreader:       NewProofreader(responseProcessor),
 	}
 }
 
@@ -42,34 +90,108 @@ func (c *StreamCoordinator) CoordinateEvaluation(
 ) error {
 	defer close(resultChan)
 
+	progress := c.latencyTracker.NewProgress(parallelSteps)
+
 	// 发送初始化消息
-	c.sendProgress(resultChan, "init", "开始作文批改", 0)
+	c.sendProgress(resultChan, "init", "开始作文批改", 0, progress.ETASeconds())
 
+	essayInfoStart := time.Now()
 	essayInfoClient := clients.CreateEssayInfoClient()
-	essayInfo, err := essayInfoClient.GetEssayInfo(ctx, req)
+	var essayInfo *dto_evaluate.APIEssayInfo
+	err := c.retryExecutor.Execute(withAuditStep(ctx, "essay_info"), func(ctx context.Context) error {
+		var err error
+		essayInfo, err = essayInfoClient.GetEssayInfo(ctx, req)
+		return err
+	}, "essay_info")
+	localEssayInfo := false
 	if err != nil {
+		if ctx.Err() != nil {
+			resultChan <- &model.StreamEvaluateResponse{
+				Type:      "error",
+				Step:      "essay_info",
+				Message:   "获取作文信息失败",
+				Data:      stepErrorData("essay_info", err),
+				Timestamp: time.Now().Unix(),
+			}
+			return err
+		}
+		// 作文信息服务不可用时降级为本地估算，后续步骤照常进行
+		logrus.Warnf("获取作文信息失败，使用本地估算: %v", err)
+		essayInfo = c.textMetrics.Analyze(req)
+		localEssayInfo = true
 		resultChan <- &model.StreamEvaluateResponse{
-			Type:      "error",
+			Type:      "warning",
 			Step:      "essay_info",
-			Message:   "获取作文信息失败",
-			Data:      &model.StreamErrorData{Error: err.Error(), Step: "essay_info"},
+			Message:   "作文信息服务不可用，分段分句和字数等统计为本地估算",
+			Data:      stepErrorData("essay_info", err),
 			Timestamp: time.Now().Unix(),
 		}
-		return err
+	} else {
+		c.latencyTracker.Observe("essay_info", time.Since(essayInfoStart))
 	}
+	progress.StartParallel()
 
 	// 构建响应结构
 	response := &model.EvaluateResponse{}
 	c.responseProcessor.ProcessEssayInfo(essayInfo, req, response)
+	if localEssayInfo {
+		c.textMetrics.MarkApproximate(&response.EssayInfo)
+	}
 	c.responseProcessor.InitializeResponse(response, modelVersion)
+	// 本地校对不依赖上游，先写入结果，语法检查完成后与其合并去重
+	if n := c.proofreader.Proofread(response); n > 0 {
+		logrus.Infof("本地校对发现 %d 处问题", n)
+	}
+	// 题干要求只依赖字数、文体和标题，随作文信息一起给出
+	response.AIEvaluation.RequirementCompliance = c.compliance.Analyze(req, response)
+
+	// 按文体选择批改配置：执行的步骤、维度权重、专项检查和提示语
+	profile := c.genreProfiles.Select(response.EssayInfo.EssayType)
+	if profile != nil {
+		logrus.Infof("使用文体配置 [%s]，文体: %s", profile.Name(), response.EssayInfo.EssayType)
+	}
+	// 按权重分配的各维度满分只用于评分（含影子流量的候选评分），不写回请求：持久化、审计、影子对比记录和回放看到的都是客户端原始请求
+	scoringReq, weighted := profile.WeightedRequest(req, response.EssayInfo.AllScore)
+	if weighted {
+		logrus.Infof("按文体权重分配各维度满分: 内容%d 表达%d 结构%d 发展%d",
+			lo.FromPtr(scoringReq.ContentScore), lo.FromPtr(scoringReq.ExpressionScore), lo.FromPtr(scoringReq.StructureScore), lo.FromPtr(scoringReq.DevelopmentScore))
+	}
+	response.AIEvaluation.GenreEvaluation = profile.Evaluate(response.EssayInfo.EssayType, response.Text)
+	var steps []string
+	for _, step := range parallelSteps {
+		if profile.RunsStep(step) {
+			steps = append(steps, step)
+		} else {
+			progress.Skip(step)
+		}
+	}
 
 	// 发送作文信息完成消息
-	c.sendProgress(resultChan, "essay_info", "作文信息分析完成", 15,
-		&model.StreamInitData{Title: response.Title, Text: response.Text, EssayInfo: response.EssayInfo})
+	c.sendProgress(resultChan, "essay_info", profile.StepMessage("essay_info", "作文信息分析完成"), progress.Progress(), progress.ETASeconds(),
+		&model.StreamInitData{
+			Title:                 response.Title,
+			Text:                  response.Text,
+			EssayInfo:             response.EssayInfo,
+			RequirementCompliance: response.AIEvaluation.RequirementCompliance,
+			GenreEvaluation:       response.AIEvaluation.GenreEvaluation,
+		})
+
+	// 范文对比只依赖正文，不调用上游，在并行步骤开始前完成
+	if comparison := c.reference.Compare(req, response); comparison != nil {
+		response.AIEvaluation.ReferenceComparison = comparison
+		c.sendProgress(resultChan, "reference", profile.StepMessage("reference", "范文对比完成"), progress.Progress(), progress.ETASeconds(),
+			model.AIEvaluation{ReferenceComparison: comparison})
+	}
 
-	apiResultChan := make(chan *APIResult, 9)
+	apiResultChan := make(chan *APIResult, len(parallelSteps))
 	var wg sync.WaitGroup
-	wg.Add(7)
+	launch := func(step string, apiFunc func(ctx context.Context) (any, error)) {
+		if !profile.RunsStep(step) {
+			return
+		}
+		wg.Add(1)
+		go c.callAPIAsync(ctx, &wg, step, apiFunc, apiResultChan)
+	}
 
 	essay := map[string]any{
 		"title": req.Title,
@@ -78,42 +200,65 @@ func (c *StreamCoordinator) CoordinateEvaluation(
 		"type":  req.EssayType,
 	}
 
-	go c.callAPIAsync(ctx, &wg, "word_sentence", func() (any, error) {
+	// 影子流量：被采样的请求同时在后台调用候选上游，不影响本次响应；候选评分与主流程一样使用按文体加权后的请求
+	shadow := c.shadowRunner.Sample()
+	shadow.Start(ctx, req, scoringReq, essay)
+
+	launch("word_sentence", func(ctx context.Context) (any, error) {
 		return clients.CreateWordSentenceClient().Evaluate(ctx, essay)
-	}, apiResultChan)
+	})
 
-	go c.callAPIAsync(ctx, &wg, "grammar", func() (any, error) {
+	launch("grammar", func(ctx context.Context) (any, error) {
 		return clients.CreateGrammarClient().Check(ctx, essay)
-	}, apiResultChan)
+	})
 
-	go c.callAPIAsync(ctx, &wg, "overall", func() (any, error) {
+	launch("overall", func(ctx context.Context) (any, error) {
 		return clients.CreateOverallClient().Evaluate(ctx, essay)
-	}, apiResultChan)
+	})
 
-	go c.callAPIAsync(ctx, &wg, "suggestion", func() (any, error) {
+	launch("suggestion", func(ctx context.Context) (any, error) {
 		return clients.CreateSuggestionClient().Generate(ctx, essay)
-	}, apiResultChan)
+	})
 
-	go c.callAPIAsync(ctx, &wg, "paragraph", func() (any, error) {
+	launch("paragraph", func(ctx context.Context) (any, error) {
 		return clients.CreateParagraphClient().Evaluate(ctx, essay)
-	}, apiResultChan)
+	})
 
-	go c.callAPIAsync(ctx, &wg, "score", func() (any, error) {
-		return clients.CreateScoreClient().Calculate(ctx, essay, req)
-	}, apiResultChan)
+	totals := scoreTotals(scoringReq, response)
+	launch("score", func(ctx context.Context) (any, error) {
+		if c.ensembleScorer != nil {
+			// 各次分数聚合时尚未校正，分歧按上游分制的满分判断
+			return c.ensembleScorer.Calculate(ctx, essay, scoringReq, c.scoreNormalizer.UpstreamTotals(totals))
+		}
+		return clients.CreateScoreClient().Calculate(ctx, essay, scoringReq)
+	})
 
 	// 润色流式处理（特殊处理）
-	go c.callPolishingStreamAsync(ctx, &wg, clients, essay, apiResultChan, response)
+	if profile.RunsStep("polishing") {
+		wg.Add(1)
+		go c.callPolishingStreamAsync(ctx, &wg, clients, essay, apiResultChan, response)
+	}
 
 	go func() {
 		wg.Wait()
 		close(apiResultChan)
 	}()
 
-	c.aggregateResultsRealtime(response, resultChan, apiResultChan, req)
+	c.aggregateResultsRealtime(response, resultChan, apiResultChan, scoringReq, progress, shadow, profile, len(steps))
+
+	// 客户端取消或断开时各步骤提前结束，结果不完整，不发送完成消息
+	if err := ctx.Err(); err != nil {
+		logrus.Warnf("批改已取消，不发送完成消息: %v", err)
+		shadow.Finish()
+		return err
+	}
+
+	// 评语依据需要好句、语法和段落点评都已就绪，只在最终结果中给出
+	c.evidenceLinker.Link(response)
 
 	// 发送完成消息
 	c.sendComplete(resultChan, response)
+	shadow.Finish()
 
 	return nil
 }
@@ -123,7 +268,7 @@ func (c *StreamCoordinator) callAPIAsync(
 	ctx context.Context,
 	wg *sync.WaitGroup,
 	stepName string,
-	apiFunc func() (any, error),
+	apiFunc func(ctx context.Context) (any, error),
 	resultChan chan<- *APIResult,
 ) {
 	defer wg.Done()
@@ -132,8 +277,8 @@ func (c *StreamCoordinator) callAPIAsync(
 	var result any
 	var err error
 
-	err = c.retryExecutor.Execute(ctx, func() error {
-		result, err = apiFunc()
+	err = c.retryExecutor.Execute(withAuditStep(ctx, stepName), func(ctx context.Context) error {
+		result, err = apiFunc(ctx)
 		return err
 	}, stepName)
 
@@ -143,12 +288,14 @@ func (c *StreamCoordinator) callAPIAsync(
 		logrus.Errorf("API调用失败 [%s] 耗时: %v, 错误: %v", stepName, elapsed, err)
 	} else {
 		logrus.Infof("API调用成功 [%s] 耗时: %v", stepName, elapsed)
+		c.latencyTracker.Observe(stepName, elapsed)
 	}
 
 	resultChan <- &APIResult{
-		Step: stepName,
-		Data: result,
-		Err:  err,
+		Step:    stepName,
+		Data:    result,
+		Err:     err,
+		Elapsed: elapsed,
 	}
 }
 
@@ -167,12 +314,14 @@ func (c *StreamCoordinator) callPolishingStreamAsync(
 	streamChan := make(chan string, 10)
 	errCh := make(chan error, 1)
 	processedAny := false
+	// 原始润色段落，供影子流量对比
+	chunks := []model.APIPolishingContent{}
 
 	// 启动流式请求
 	go func() {
 		defer close(streamChan)
 		polishingClient := clients.CreatePolishingClient()
-		if err := polishingClient.PolishStream(ctx, essay, streamChan); err != nil {
+		if err := polishingClient.PolishStream(withAuditStep(ctx, "polishing"), essay, streamChan); err != nil {
 			logrus.Errorf("流式润色API调用失败: %v", err)
 			errCh <- err
 		}
@@ -201,23 +350,26 @@ func (c *StreamCoordinator) callPolishingStreamAsync(
 			}
 
 			processedAny = true
+			chunks = append(chunks, polishing)
 			logrus.Infof("处理润色段落 %d 完成", polishing.ParagraphIdx)
 
 		case streamErr := <-errCh:
 			logrus.Errorf("润色流式处理错误: %v", streamErr)
 			apiResultChan <- &APIResult{
-				Step: "polishing",
-				Data: nil,
-				Err:  streamErr,
+				Step:    "polishing",
+				Data:    nil,
+				Err:     streamErr,
+				Elapsed: time.Since(startTime),
 			}
 			return
 
 		case <-ctx.Done():
 			logrus.Warn("润色处理被取消")
 			apiResultChan <- &APIResult{
-				Step: "polishing",
-				Data: nil,
-				Err:  ctx.Err(),
+				Step:    "polishing",
+				Data:    nil,
+				Err:     ctx.Err(),
+				Elapsed: time.Since(startTime),
 			}
 			return
 		}
@@ -227,20 +379,22 @@ polishingDone:
 	elapsed := time.Since(startTime)
 	if processedAny {
 		logrus.Infof("润色流式处理完成 耗时: %v", elapsed)
+		c.latencyTracker.Observe("polishing", elapsed)
 	} else {
 		logrus.Warn("未处理任何润色内容")
 	}
 
 	// 发送完成信号
 	apiResultChan <- &APIResult{
-		Step: "polishing",
-		Data: "done",
-		Err:  nil,
+		Step:    "polishing",
+		Data:    chunks,
+		Err:     nil,
+		Elapsed: elapsed,
 	}
 }
 
 // sendProgress 发送进度消息
-func (c *StreamCoordinator) sendProgress(ch chan<- *model.StreamEvaluateResponse, step, message string, progress int, data ...any) {
+func (c *StreamCoordinator) sendProgress(ch chan<- *model.StreamEvaluateResponse, step, message string, progress, etaSeconds int, data ...any) {
 	var progressData any
 	if len(data) > 0 {
 		progressData = data[0]
@@ -248,12 +402,13 @@ func (c *StreamCoordinator) sendProgress(ch chan<- *model.StreamEvaluateResponse
 
 	select {
 	case ch <- &model.StreamEvaluateResponse{
-		Type:      "progress",
-		Step:      step,
-		Progress:  progress,
-		Message:   message,
-		Data:      progressData,
-		Timestamp: time.Now().Unix(),
+		Type:       "progress",
+		Step:       step,
+		Progress:   progress,
+		ETASeconds: etaSeconds,
+		Message:    message,
+		Data:       progressData,
+		Timestamp:  time.Now().Unix(),
 	}:
 	default:
 		logrus.Warn("进度channel已满，跳过消息")
@@ -272,35 +427,77 @@ func (c *StreamCoordinator) sendComplete(ch chan<- *model.StreamEvaluateResponse
 	}
 }
 
+// sendStepError 发送单个步骤失败消息
+func (c *StreamCoordinator) sendStepError(ch chan<- *model.StreamEvaluateResponse, result *APIResult, progress, etaSeconds int) {
+	ch <- &model.StreamEvaluateResponse{
+		Type:       "step_error",
+		Step:       result.Step,
+		Progress:   progress,
+		ETASeconds: etaSeconds,
+		Message:    getStepErrorMessage(result.Step),
+		Data:       stepErrorData(result.Step, result.Err),
+		Timestamp:  time.Now().Unix(),
+	}
+}
+
+// stepErrorData 把步骤错误转换为流式错误数据
+func stepErrorData(step string, err error) *model.StreamErrorData {
+	stepErr := AsStepError(step, err)
+	return &model.StreamErrorData{
+		Error:    err.Error(),
+		Step:     step,
+		Kind:     string(stepErr.Kind),
+		Code:     stepErr.Code,
+		Attempts: stepErr.Attempts,
+	}
+}
+
 // aggregateResultsRealtime 实时聚合处理（谁先完成谁先处理，动态progress）
 func (c *StreamCoordinator) aggregateResultsRealtime(
 	response *model.EvaluateResponse,
 	progressChan chan<- *model.StreamEvaluateResponse,
 	apiResultChan <-chan *APIResult,
 	req *model.EvaluateRequest,
+	progress *ProgressEstimator,
+	shadow *ShadowRun,
+	profile *genre.Profile,
+	totalAPIs int,
 ) {
-	const totalAPIs = 9
-	const baseProgress = 15  // essay_info完成后的进度
-	const progressRange = 75 // 从15到90的范围
-
 	completedCount := 0
 	var errors []error
+	// 偏题判定需要总评的切题度和评分结果，两个步骤都成功后执行一次
+	var apiScore *model.APIScore
+	overallDone, offTopicChecked := false, false
 
 	// 实时监听API完成结果
 	for result := range apiResultChan {
 		completedCount++
 
-		// 动态计算progress：谁先完成谁的progress就小
-		currentProgress := baseProgress + int(float64(completedCount)/float64(totalAPIs)*float64(progressRange))
+		// 按历史耗时加权计算progress：耗时越长的步骤完成时进度增长越多
+		currentProgress := progress.Complete(result.Step)
+		shadow.RecordPrimary(result)
 
 		if result.Err != nil {
 			logrus.Errorf("API [%s] 执行失败: %v", result.Step, result.Err)
 			errors = append(errors, result.Err)
+			// 单个步骤失败不终止批改，以step_error告知客户端该部分缺失
+			c.sendStepError(progressChan, result, currentProgress, progress.ETASeconds())
 			continue
 		}
 
 		// 根据step类型处理数据并发送进度
-		c.processAndSendProgress(result, response, progressChan, currentProgress, req)
+		c.processAndSendProgress(result, response, progressChan, currentProgress, progress.ETASeconds(), req, profile)
+
+		switch result.Step {
+		case "overall":
+			overallDone = result.Data != nil
+		case "score":
+			apiScore, _ = result.Data.(*model.APIScore)
+		}
+		if !offTopicChecked && overallDone && apiScore != nil {
+			offTopicChecked = true
+			c.applyOffTopicGate(response, progressChan, req, apiScore, currentProgress, progress.ETASeconds())
+		}
 
 		logrus.Infof("进度更新: [%s] %d%% (%d/%d 完成)", result.Step, currentProgress, completedCount, totalAPIs)
 	}
@@ -318,7 +515,9 @@ func (c *StreamCoordinator) processAndSendProgress(
 	response *model.EvaluateResponse,
 	progressChan chan<- *model.StreamEvaluateResponse,
 	progress int,
+	etaSeconds int,
 	req *model.EvaluateRequest,
+	profile *genre.Profile,
 ) {
 	if result.Data == nil {
 		logrus.Warnf("API [%s] 返回数据为空", result.Step)
@@ -331,13 +530,13 @@ func (c *StreamCoordinator) processAndSendProgress(
 	case "word_sentence":
 		if wordSentence, ok := result.Data.(*dto_evaluate.APIWordSentence); ok {
 			c.responseProcessor.ProcessWordSentence(wordSentence, response)
-			stepData = model.AIEvaluation{WordSentenceEvaluation: response.AIEvaluation.WordSentenceEvaluation}
+			stepData = model.AIEvaluation{WordSentenceEvaluation: cloneWordSentenceEvaluation(response.AIEvaluation.WordSentenceEvaluation)}
 		}
 
 	case "grammar":
 		if grammar, ok := result.Data.(*dto_evaluate.APIGrammarInfo); ok {
 			c.responseProcessor.ProcessGrammar(grammar, response)
-			stepData = model.AIEvaluation{WordSentenceEvaluation: response.AIEvaluation.WordSentenceEvaluation}
+			stepData = model.AIEvaluation{WordSentenceEvaluation: cloneWordSentenceEvaluation(response.AIEvaluation.WordSentenceEvaluation)}
 		}
 
 	case "overall":
@@ -361,6 +560,15 @@ func (c *StreamCoordinator) processAndSendProgress(
 	case "score":
 		if score, ok := result.Data.(*model.APIScore); ok {
 			c.responseProcessor.ProcessScore(score, req, response)
+			// 先校正上游分数，分类和分项折算都基于校正后的分数
+			scoreEvaluation := &response.AIEvaluation.ScoreEvaluation
+			scoreEvaluation.Confidence = score.Confidence
+			scoreEvaluation.Adjustments = c.scoreNormalizer.Normalize(&scoreEvaluation.Scores, scoreTotals(req, response))
+			if len(scoreEvaluation.Adjustments) > 0 {
+				logrus.Warnf("上游分数已校正: %+v", scoreEvaluation.Adjustments)
+				c.responseProcessor.FormatScoreTotals(req, response)
+			}
+			c.classifyScore(req, response, score)
 			stepData = model.AIEvaluation{ScoreEvaluation: response.AIEvaluation.ScoreEvaluation}
 		}
 
@@ -374,7 +582,76 @@ func (c *StreamCoordinator) processAndSendProgress(
 	}
 
 	// 发送进度消息
-	c.sendProgress(progressChan, result.Step, getStepMessage(result.Step), progress, stepData)
+	c.sendProgress(progressChan, result.Step, profile.StepMessage(result.Step, getStepMessage(result.Step)), progress, etaSeconds, stepData)
+}
+
+// classifyScore 按当前分数做考试标准分类和评分项折算，分数被限分后需重新计算
+func (c *StreamCoordinator) classifyScore(req *model.EvaluateRequest, response *model.EvaluateResponse, score *model.APIScore) {
+	scoreEvaluation := &response.AIEvaluation.ScoreEvaluation
+	scoreEvaluation.ExamBand = c.bandClassifier.Classify(response.EssayInfo.Grade, scoreEvaluation.Scores, scoreTotals(req, response))
+	scoreEvaluation.Criteria = rubric.Breakdown(req.Rubric, scoreEvaluation.Scores, criterionResults(score))
+}
+
+// applyOffTopicGate 总评和评分都完成后按切题度判定偏题，偏题时限分并发送warning消息
+func (c *StreamCoordinator) applyOffTopicGate(
+	response *model.EvaluateResponse,
+	progressChan chan<- *model.StreamEvaluateResponse,
+	req *model.EvaluateRequest,
+	score *model.APIScore,
+	progress int,
+	etaSeconds int,
+) {
+	scoreEvaluation := &response.AIEvaluation.ScoreEvaluation
+	relevance := response.AIEvaluation.OverallEvaluation.TopicRelevanceScore
+	if !c.offTopicGate.Apply(scoreEvaluation, relevance, scoreTotals(req, response)) {
+		return
+	}
+	logrus.Warnf("作文偏题已限分: %s", scoreEvaluation.OffTopicReason)
+
+	c.responseProcessor.FormatScoreTotals(req, response)
+	c.classifyScore(req, response, score)
+
+	progressChan <- &model.StreamEvaluateResponse{
+		Type:       "warning",
+		Step:       "off_topic",
+		Progress:   progress,
+		ETASeconds: etaSeconds,
+		Message:    scoreEvaluation.OffTopicReason,
+		Data:       model.AIEvaluation{ScoreEvaluation: *scoreEvaluation},
+		Timestamp:  time.Now().Unix(),
+	}
+}
+
+// scoreTotals 总分及请求中指定的各维度满分
+func scoreTotals(req *model.EvaluateRequest, response *model.EvaluateResponse) rubric.Totals {
+	totals := rubric.Totals{All: response.EssayInfo.AllScore, Dimensions: make(map[string]int64)}
+	for dimension, total := range map[string]*int64{
+		rubric.DimensionContent:     req.ContentScore,
+		rubric.DimensionExpression:  req.ExpressionScore,
+		rubric.DimensionStructure:   req.StructureScore,
+		rubric.DimensionDevelopment: req.DevelopmentScore,
+	} {
+		if total != nil {
+			totals.Dimensions[dimension] = *total
+		}
+	}
+	// 卷面满分只在有原稿图片时计入
+	if req.HasImages() && req.AppearanceScore != nil {
+		totals.Dimensions[rubric.DimensionAppearance] = *req.AppearanceScore
+	}
+	return totals
+}
+
+// criterionResults 上游按评分项返回的得分
+func criterionResults(score *model.APIScore) map[string]rubric.CriterionResult {
+	if len(score.Result.Criteria) == 0 {
+		return nil
+	}
+	results := make(map[string]rubric.CriterionResult, len(score.Result.Criteria))
+	for _, criterion := range score.Result.Criteria {
+		results[criterion.ID] = rubric.CriterionResult{Score: criterion.Score, Comment: criterion.Comment}
+	}
+	return results
 }
 
 // getStepMessage 获取步骤的提示消息
@@ -393,3 +670,20 @@ func getStepMessage(step string) string {
 	}
 	return step + "完成"
 }
+
+// getStepErrorMessage 获取步骤失败的提示消息
+func getStepErrorMessage(step string) string {
+	messages := map[string]string{
+		"word_sentence": "词句评估失败",
+		"grammar":       "语法检查失败",
+		"overall":       "总体评价失败",
+		"suggestion":    "建议生成失败",
+		"paragraph":     "段落评估失败",
+		"score":         "评分失败",
+		"polishing":     "作文润色失败",
+	}
+	if msg, ok := messages[step]; ok {
+		return msg
+	}
+	return step + "失败"
+}
@@ -0,0 +1,187 @@
+package genre
+
+import (
+	"essay-stateless/internal/model"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// 文体专项检查
+const (
+	CheckThesis            = "thesis"
+	CheckArgument          = "argument"
+	CheckExplanationMethod = "explanation_method"
+	CheckNarrativeElements = "narrative_elements"
+)
+
+// EvidenceSentence 专项检查依据的来源：原文句子
+const EvidenceSentence = "sentence"
+
+var checkers = map[string]func(text [][]string) model.GenreCheck{
+	CheckThesis:            checkThesis,
+	CheckArgument:          checkArgument,
+	CheckExplanationMethod: checkExplanationMethod,
+	CheckNarrativeElements: checkNarrativeElements,
+}
+
+// cue 一类文本特征及其识别规则
+type cue struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var (
+	thesisPattern = regexp.MustCompile(`我认为|我觉得|我以为|应该|应当|必须|才能|只有|由此可见|总而言之|总之|因此|所以说`)
+
+	factArgumentPattern   = regexp.MustCompile(`例如|比如|譬如|就拿|为例|[0-9０-９]{3,4}年|曾经`)
+	reasonArgumentPattern = regexp.MustCompile(`名言|俗话说|古人云|常言道|曾说|说过|正如|所言|有言`)
+
+	explanationMethods = []cue{
+		{"举例子", regexp.MustCompile(`例如|比如|譬如|就拿|为例`)},
+		{"列数字", regexp.MustCompile(`[0-9０-９]+(?:\.[0-9]+)?\s*(?:米|千米|公里|厘米|毫米|吨|千克|公斤|克|年|天|小时|分钟|秒|%|％|度|万|亿)`)},
+		{"打比方", regexp.MustCompile(`好像|好比|仿佛|如同|犹如|像`)},
+		{"作比较", regexp.MustCompile(`相比|相较|比起|不如|相当于`)},
+		{"下定义", regexp.MustCompile(`是指|叫做|称为|称作|所谓`)},
+		{"分类别", regexp.MustCompile(`分为|分成|可分|一类|另一类|种类`)},
+		{"引资料", regexp.MustCompile(`据统计|记载|资料显示|研究表明|据调查`)},
+	}
+
+	narrativeElements = []cue{
+		{"时间", regexp.MustCompile(`那天|那年|那时|当时|有一次|一天|早上|早晨|上午|中午|下午|傍晚|晚上|夜里|星期|周末|假期|暑假|寒假|春天|夏天|秋天|冬天|[0-9一二三四五六七八九十]+[年月日号点]`)},
+		{"地点", regexp.MustCompile(`在[^，。！？；]{1,8}[里上中下旁边前后外]|学校|教室|家里|公园|医院|操场|路上|超市|门口|村子|街上`)},
+		{"人物", regexp.MustCompile(`我|妈妈|爸爸|母亲|父亲|老师|同学|奶奶|爷爷|外婆|外公|朋友|他|她`)},
+	}
+)
+
+// checkThesis 中心论点：优先在开头两段找表明观点的句子，其次在结尾段
+func checkThesis(text [][]string) model.GenreCheck {
+	check := model.GenreCheck{Kind: CheckThesis, Name: "中心论点"}
+	opening := min(len(text), 2)
+	for p := range opening {
+		if ref, ok := findSentence(text, p, thesisPattern); ok {
+			check.Passed = true
+			check.Detail = "开篇提出了论点"
+			check.Evidence = []model.EvidenceRef{ref}
+			return check
+		}
+	}
+	if last := len(text) - 1; last >= opening {
+		if ref, ok := findSentence(text, last, thesisPattern); ok {
+			check.Passed = true
+			check.Detail = "结尾点明了论点，建议在开篇亮明观点"
+			check.Evidence = []model.EvidenceRef{ref}
+			return check
+		}
+	}
+	check.Detail = "未找到明确表明观点的句子，建议在开篇提出中心论点"
+	return check
+}
+
+// checkArgument 论据：事实论据（举例、史实）与道理论据（名言、俗语）合计不少于2处
+func checkArgument(text [][]string) model.GenreCheck {
+	check := model.GenreCheck{Kind: CheckArgument, Name: "论据"}
+	facts, reasons := 0, 0
+	eachSentence(text, func(p, s int, sentence string) {
+		switch {
+		case factArgumentPattern.MatchString(sentence):
+			facts++
+			check.Evidence = append(check.Evidence, sentenceRef(p, s, sentence, "事实论据"))
+		case reasonArgumentPattern.MatchString(sentence):
+			reasons++
+			check.Evidence = append(check.Evidence, sentenceRef(p, s, sentence, "道理论据"))
+		}
+	})
+	check.Passed = facts+reasons >= 2
+	check.Detail = fmt.Sprintf("事实论据%d处，道理论据%d处", facts, reasons)
+	if !check.Passed {
+		check.Detail += "，论据不足"
+	}
+	return check
+}
+
+// checkExplanationMethod 说明方法：使用不少于2种
+func checkExplanationMethod(text [][]string) model.GenreCheck {
+	check := model.GenreCheck{Kind: CheckExplanationMethod, Name: "说明方法"}
+	used := matchCues(text, explanationMethods, &check)
+	check.Passed = len(used) >= 2
+	if len(used) == 0 {
+		check.Detail = "未识别到说明方法"
+	} else {
+		check.Detail = "使用了" + strings.Join(used, "、")
+	}
+	return check
+}
+
+// checkNarrativeElements 记叙要素：时间、地点、人物齐全
+func checkNarrativeElements(text [][]string) model.GenreCheck {
+	check := model.GenreCheck{Kind: CheckNarrativeElements, Name: "记叙要素"}
+	found := matchCues(text, narrativeElements, &check)
+	var missing []string
+	for _, element := range narrativeElements {
+		if !containsString(found, element.name) {
+			missing = append(missing, element.name)
+		}
+	}
+	check.Passed = len(missing) == 0
+	if check.Passed {
+		check.Detail = "时间、地点、人物交代清楚"
+	} else {
+		check.Detail = "未交代" + strings.Join(missing, "、")
+	}
+	return check
+}
+
+// matchCues 返回原文中出现的特征，每种特征以第一次出现的句子为依据
+func matchCues(text [][]string, cues []cue, check *model.GenreCheck) []string {
+	var found []string
+	for _, c := range cues {
+		for p := range text {
+			if ref, ok := findSentence(text, p, c.pattern); ok {
+				ref.Note = c.name
+				check.Evidence = append(check.Evidence, ref)
+				found = append(found, c.name)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// findSentence 段内第一个匹配的句子
+func findSentence(text [][]string, p int, pattern *regexp.Regexp) (model.EvidenceRef, bool) {
+	for s, sentence := range text[p] {
+		if pattern.MatchString(sentence) {
+			return sentenceRef(p, s, sentence, ""), true
+		}
+	}
+	return model.EvidenceRef{}, false
+}
+
+func eachSentence(text [][]string, fn func(p, s int, sentence string)) {
+	for p, paragraph := range text {
+		for s, sentence := range paragraph {
+			fn(p, s, sentence)
+		}
+	}
+}
+
+func sentenceRef(p, s int, sentence, note string) model.EvidenceRef {
+	return model.EvidenceRef{
+		Source:         EvidenceSentence,
+		Polarity:       "positive",
+		ParagraphIndex: p,
+		SentenceIndex:  s,
+		Text:           sentence,
+		Note:           note,
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
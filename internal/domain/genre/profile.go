@@ -0,0 +1,176 @@
+package genre
+
+import (
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Profiles 按文体选择的批改配置
+type Profiles struct {
+	profiles []*Profile
+}
+
+// NewProfiles 加载文体配置，未配置时返回nil；knownSteps 为可配置的步骤，未知的步骤和专项检查记录警告后忽略
+func NewProfiles(cfgs []config.GenreProfileConfig, knownSteps []string) *Profiles {
+	if len(cfgs) == 0 {
+		return nil
+	}
+	profiles := &Profiles{}
+	for _, cfg := range cfgs {
+		profile := &Profile{
+			name:       cfg.Name,
+			essayTypes: cfg.EssayTypes,
+			weights:    cfg.Weights,
+			messages:   cfg.Messages,
+		}
+		if len(profile.essayTypes) == 0 {
+			profile.essayTypes = []string{cfg.Name}
+		}
+		for _, step := range cfg.Steps {
+			if !containsString(knownSteps, step) {
+				logrus.Warnf("文体配置 [%s] 未知的步骤 %s，已忽略", cfg.Name, step)
+				continue
+			}
+			if profile.steps == nil {
+				profile.steps = make(map[string]bool, len(cfg.Steps))
+			}
+			profile.steps[step] = true
+		}
+		if len(cfg.Steps) > 0 && profile.steps == nil {
+			logrus.Warnf("文体配置 [%s] 没有有效的步骤，按全部步骤执行", cfg.Name)
+		}
+		for _, check := range cfg.Checks {
+			if _, ok := checkers[check]; !ok {
+				logrus.Warnf("文体配置 [%s] 未知的专项检查 %s，已忽略", cfg.Name, check)
+				continue
+			}
+			profile.checks = append(profile.checks, check)
+		}
+		profiles.profiles = append(profiles.profiles, profile)
+	}
+	return profiles
+}
+
+// Select 按文体取第一个匹配的配置，没有匹配时返回nil
+func (p *Profiles) Select(essayType string) *Profile {
+	if p == nil || essayType == "" {
+		return nil
+	}
+	for _, profile := range p.profiles {
+		for _, t := range profile.essayTypes {
+			if t != "" && strings.Contains(essayType, t) {
+				return profile
+			}
+		}
+	}
+	return nil
+}
+
+// Profile 单个文体的批改配置，所有方法对nil接收者安全（nil表示按默认流程批改）
+type Profile struct {
+	name       string
+	essayTypes []string
+	steps      map[string]bool
+	weights    config.GenreWeightsConfig
+	checks     []string
+	messages   map[string]string
+}
+
+// Name 配置名称
+func (p *Profile) Name() string {
+	if p == nil {
+		return ""
+	}
+	return p.name
+}
+
+// RunsStep 是否执行该并行步骤
+func (p *Profile) RunsStep(step string) bool {
+	return p == nil || p.steps == nil || p.steps[step]
+}
+
+// StepMessage 步骤完成提示语，未配置时使用fallback
+func (p *Profile) StepMessage(step, fallback string) string {
+	if p == nil {
+		return fallback
+	}
+	if message, ok := p.messages[step]; ok && message != "" {
+		return message
+	}
+	return fallback
+}
+
+// WeightedRequest 请求未指定量表和任何维度满分时，按权重把总分分配到各维度，返回分配后的请求副本和是否分配
+//
+// 不修改传入的请求，未分配时原样返回。有原稿图片且指定了卷面满分时，先从总分中扣除卷面分。
+func (p *Profile) WeightedRequest(req *model.EvaluateRequest, total int64) (*model.EvaluateRequest, bool) {
+	if p == nil || req.Rubric != nil || total <= 0 {
+		return req, false
+	}
+	if req.ContentScore != nil || req.ExpressionScore != nil || req.StructureScore != nil || req.DevelopmentScore != nil {
+		return req, false
+	}
+	if req.HasImages() && req.AppearanceScore != nil {
+		total -= *req.AppearanceScore
+	}
+
+	weights := []int64{p.weights.Content, p.weights.Expression, p.weights.Structure, p.weights.Development}
+	shares, ok := distribute(total, weights)
+	if !ok {
+		return req, false
+	}
+	weighted := *req
+	targets := []**int64{&weighted.ContentScore, &weighted.ExpressionScore, &weighted.StructureScore, &weighted.DevelopmentScore}
+	for i, share := range shares {
+		if weights[i] > 0 {
+			*targets[i] = &share
+		}
+	}
+	return &weighted, true
+}
+
+// Evaluate 执行配置的专项检查，nil配置返回nil
+func (p *Profile) Evaluate(essayType string, text [][]string) *model.GenreEvaluation {
+	if p == nil {
+		return nil
+	}
+	evaluation := &model.GenreEvaluation{Profile: p.name, EssayType: essayType}
+	for _, check := range p.checks {
+		evaluation.Checks = append(evaluation.Checks, checkers[check](text))
+	}
+	return evaluation
+}
+
+// distribute 按权重分配总分（最大余数法，保证总和不变），权重全为0或有负数时返回false
+func distribute(total int64, weights []int64) ([]int64, bool) {
+	var sum int64
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, false
+		}
+		sum += weight
+	}
+	if sum == 0 || total <= 0 {
+		return nil, false
+	}
+
+	shares := make([]int64, len(weights))
+	order := make([]int, len(weights))
+	var assigned int64
+	for i, weight := range weights {
+		shares[i] = total * weight / sum
+		assigned += shares[i]
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return total*weights[order[a]]%sum > total*weights[order[b]]%sum
+	})
+	for i := int64(0); i < total-assigned; i++ {
+		shares[order[i]]++
+	}
+	return shares, true
+}
@@ -8,29 +8,35 @@ import (
 	"io"
 	"net/http"
 
+	"essay-stateless/pkg/httpclient"
+
 	openai "github.com/sashabaranov/go-openai"
 	"github.com/sirupsen/logrus"
 )
 
 // ArkProvider ARK OCR提供商
 type ArkProvider struct {
-	client  *openai.Client
-	model   string
-	apiKey  string
-	baseURL string
+	client     *openai.Client
+	httpClient *http.Client
+	model      string
+	apiKey     string
+	baseURL    string
 }
 
 // NewArkProvider 创建ARK OCR提供商
 func NewArkProvider(apiKey, baseURL, model string) *ArkProvider {
 	config := openai.DefaultConfig(apiKey)
 	config.BaseURL = baseURL
+	httpClient := httpclient.NewHTTPClient()
+	config.HTTPClient = httpClient
 	client := openai.NewClientWithConfig(config)
 
 	return &ArkProvider{
-		client:  client,
-		model:   model,
-		apiKey:  apiKey,
-		baseURL: baseURL,
+		client:     client,
+		httpClient: httpClient,
+		model:      model,
+		apiKey:     apiKey,
+		baseURL:    baseURL,
 	}
 }
 
@@ -118,8 +124,7 @@ func (p *ArkProvider) callAPI(ctx context.Context, imageURL string, withTitle bo
 	req.Header.Set("Authorization", "Bearer "+p.apiKey)
 
 	// 发送请求
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("ARK OCR API调用失败: %w", err)
 	}
@@ -0,0 +1,244 @@
+package replay
+
+import (
+	"encoding/json"
+	"essay-stateless/internal/model"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// 回放的接口类型
+const (
+	KindEvaluate   = "evaluate"
+	KindOCR        = "ocr"
+	KindStatistics = "statistics"
+)
+
+// Diff 一条原始日志回放后与原响应的差异
+type Diff struct {
+	LogID      string              `json:"logId"`
+	URL        string              `json:"url"`
+	Kind       string              `json:"kind"`
+	CreateTime time.Time           `json:"createTime"`
+	Error      string              `json:"error,omitempty"`
+	Scores     []NumericDelta      `json:"scores,omitempty"`
+	Counts     []CountDelta        `json:"counts,omitempty"`
+	Comments   []CommentSimilarity `json:"comments,omitempty"`
+	Changed    bool                `json:"changed"`
+}
+
+// NumericDelta 数值差异
+type NumericDelta struct {
+	Name    string  `json:"name"`
+	Stored  float64 `json:"stored"`
+	Current float64 `json:"current"`
+	Delta   float64 `json:"delta"`
+}
+
+// CountDelta 按类型统计的标注数量差异
+type CountDelta struct {
+	Type    string `json:"type"`
+	Stored  int    `json:"stored"`
+	Current int    `json:"current"`
+	Delta   int    `json:"delta"`
+}
+
+// CommentSimilarity 评语相似度（0~1）
+type CommentSimilarity struct {
+	Name       string  `json:"name"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Differ 回放结果比较器
+type Differ struct{}
+
+// NewDiffer 创建比较器
+func NewDiffer() *Differ {
+	return &Differ{}
+}
+
+// DiffEvaluation 比较批改结果：分数、各类标注数量、评语相似度
+func (d *Differ) DiffEvaluation(stored, current *model.EvaluateResponse) *Diff {
+	diff := &Diff{Kind: KindEvaluate}
+
+	ss, cs := stored.AIEvaluation.ScoreEvaluation.Scores, current.AIEvaluation.ScoreEvaluation.Scores
+	diff.addScore("all", float64(ss.All), float64(cs.All))
+	diff.addScore("content", float64(ss.Content), float64(cs.Content))
+	diff.addScore("expression", float64(ss.Expression), float64(cs.Expression))
+	diff.addScore("structure", float64(ss.Structure), float64(cs.Structure))
+	diff.addScore("development", float64(ss.Development), float64(cs.Development))
+	diff.addScore("appearance", float64(ss.Appearance), float64(cs.Appearance))
+	diff.addScore("topicRelevance",
+		float64(stored.AIEvaluation.OverallEvaluation.TopicRelevanceScore),
+		float64(current.AIEvaluation.OverallEvaluation.TopicRelevanceScore))
+	diff.addScore("wordSentence",
+		float64(stored.AIEvaluation.WordSentenceEvaluation.WordSentenceScore),
+		float64(current.AIEvaluation.WordSentenceEvaluation.WordSentenceScore))
+
+	diff.Counts = diffCounts(CountAnnotations(stored), CountAnnotations(current))
+
+	sc, cc := stored.AIEvaluation, current.AIEvaluation
+	diff.addComment("overall", sc.OverallEvaluation.Description, cc.OverallEvaluation.Description)
+	diff.addComment("suggestion", sc.SuggestionEvaluation.SuggestionDescription, cc.SuggestionEvaluation.SuggestionDescription)
+	diff.addComment("score", sc.ScoreEvaluation.Comment, cc.ScoreEvaluation.Comment)
+	diff.addComment("score.content", sc.ScoreEvaluation.Comments.Content, cc.ScoreEvaluation.Comments.Content)
+	diff.addComment("score.expression", sc.ScoreEvaluation.Comments.Expression, cc.ScoreEvaluation.Comments.Expression)
+	diff.addComment("score.structure", sc.ScoreEvaluation.Comments.Structure, cc.ScoreEvaluation.Comments.Structure)
+	diff.addComment("score.development", sc.ScoreEvaluation.Comments.Development, cc.ScoreEvaluation.Comments.Development)
+	diff.addComment("score.appearance", sc.ScoreEvaluation.Comments.Appearance, cc.ScoreEvaluation.Comments.Appearance)
+
+	storedParagraphs := paragraphComments(stored)
+	currentParagraphs := paragraphComments(current)
+	for i := 0; i < max(len(storedParagraphs), len(currentParagraphs)); i++ {
+		diff.addComment(fmt.Sprintf("paragraph[%d]", i), at(storedParagraphs, i), at(currentParagraphs, i))
+	}
+
+	return diff
+}
+
+// DiffOCR 比较OCR结果：标题与正文相似度
+func (d *Differ) DiffOCR(stored, current *model.TitleOcrResponse) *Diff {
+	diff := &Diff{Kind: KindOCR}
+	diff.addComment("title", stored.Title, current.Title)
+	diff.addComment("content", stored.Content, current.Content)
+	return diff
+}
+
+// DiffStatistics 比较学情统计结果：所有数值字段（生成时间除外）
+func (d *Differ) DiffStatistics(stored, current *model.ClassStatisticsResponse) *Diff {
+	diff := &Diff{Kind: KindStatistics}
+
+	storedValues, currentValues := flattenNumbers(stored), flattenNumbers(current)
+	names := make(map[string]bool)
+	for name := range storedValues {
+		names[name] = true
+	}
+	for name := range currentValues {
+		names[name] = true
+	}
+	delete(names, "generatedTime")
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		diff.addScore(name, storedValues[name], currentValues[name])
+	}
+	return diff
+}
+
+func (diff *Diff) addScore(name string, stored, current float64) {
+	delta := current - stored
+	diff.Scores = append(diff.Scores, NumericDelta{Name: name, Stored: stored, Current: current, Delta: delta})
+	if math.Abs(delta) > 1e-9 {
+		diff.Changed = true
+	}
+}
+
+func (diff *Diff) addComment(name, stored, current string) {
+	if stored == "" && current == "" {
+		return
+	}
+	similarity := TextSimilarity(stored, current)
+	diff.Comments = append(diff.Comments, CommentSimilarity{Name: name, Similarity: similarity})
+	if similarity < 1 {
+		diff.Changed = true
+	}
+}
+
+// CountAnnotations 按类型统计标注数量：好句、好词/语法错误（按level2类型）、润色（按操作）
+func CountAnnotations(result *model.EvaluateResponse) map[string]int {
+	counts := make(map[string]int)
+	for _, paragraph := range result.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations {
+		for _, sentence := range paragraph {
+			if sentence.IsGoodSentence {
+				counts["好句"]++
+			}
+			for _, we := range sentence.WordEvaluations {
+				counts[we.Type["level1"]+"/"+we.Type["level2"]]++
+			}
+		}
+	}
+	for _, pe := range result.AIEvaluation.PolishingEvaluation {
+		for _, edit := range pe.Edits {
+			counts["润色/"+edit.Op]++
+		}
+	}
+	return counts
+}
+
+func diffCounts(stored, current map[string]int) []CountDelta {
+	types := make(map[string]bool)
+	for t := range stored {
+		types[t] = true
+	}
+	for t := range current {
+		types[t] = true
+	}
+
+	var deltas []CountDelta
+	for t := range types {
+		deltas = append(deltas, CountDelta{Type: t, Stored: stored[t], Current: current[t], Delta: current[t] - stored[t]})
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Type < deltas[j].Type
+	})
+	return deltas
+}
+
+func paragraphComments(result *model.EvaluateResponse) []string {
+	var comments []string
+	for _, pe := range result.AIEvaluation.ParagraphEvaluations {
+		for len(comments) <= pe.ParagraphIndex {
+			comments = append(comments, "")
+		}
+		comments[pe.ParagraphIndex] = pe.Comment
+	}
+	return comments
+}
+
+func at(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
+
+// flattenNumbers 将结构体序列化后展开为 路径→数值，数组下标记为[i]
+func flattenNumbers(v any) map[string]float64 {
+	result := make(map[string]float64)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return result
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return result
+	}
+
+	var walk func(prefix string, node any)
+	walk = func(prefix string, node any) {
+		switch value := node.(type) {
+		case map[string]any:
+			for k, child := range value {
+				name := k
+				if prefix != "" {
+					name = prefix + "." + k
+				}
+				walk(name, child)
+			}
+		case []any:
+			for i, child := range value {
+				walk(fmt.Sprintf("%s[%d]", prefix, i), child)
+			}
+		case float64:
+			result[prefix] = value
+		}
+	}
+	walk("", generic)
+	return result
+}
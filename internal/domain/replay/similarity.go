@@ -0,0 +1,58 @@
+package replay
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TextSimilarity 文本相似度：去除空白和标点后按字符二元组计算Dice系数，取值0~1
+//
+// 中文评语没有分词，二元组比单字更能反映措辞变化，又不像整句比较那样对语序过于敏感。
+func TextSimilarity(a, b string) float64 {
+	ra, rb := normalize(a), normalize(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	ga, gb := ngrams(ra), ngrams(rb)
+	total := 0
+	for _, n := range ga {
+		total += n
+	}
+	for _, n := range gb {
+		total += n
+	}
+
+	common := 0
+	for gram, n := range ga {
+		common += min(n, gb[gram])
+	}
+	return 2 * float64(common) / float64(total)
+}
+
+func normalize(s string) []rune {
+	var runes []rune
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// ngrams 字符二元组计数，单字文本退化为一元组
+func ngrams(runes []rune) map[string]int {
+	grams := make(map[string]int)
+	if len(runes) == 1 {
+		grams[string(runes)]++
+		return grams
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
@@ -0,0 +1,102 @@
+package replay
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary 一批回放结果的汇总
+type Summary struct {
+	Total   int `json:"total"`
+	Failed  int `json:"failed"`
+	Changed int `json:"changed"`
+
+	// 各分数的平均绝对差与最大绝对差
+	ScoreMeanAbsDelta map[string]float64 `json:"scoreMeanAbsDelta"`
+	ScoreMaxAbsDelta  map[string]float64 `json:"scoreMaxAbsDelta"`
+	// 各类型标注数量的总变化
+	CountDelta map[string]int `json:"countDelta"`
+	// 各评语的平均相似度与最低相似度
+	CommentMeanSimilarity map[string]float64 `json:"commentMeanSimilarity"`
+	CommentMinSimilarity  map[string]float64 `json:"commentMinSimilarity"`
+
+	scoreCounts   map[string]int
+	commentCounts map[string]int
+}
+
+// NewSummary 创建汇总
+func NewSummary() *Summary {
+	return &Summary{
+		ScoreMeanAbsDelta:     make(map[string]float64),
+		ScoreMaxAbsDelta:      make(map[string]float64),
+		CountDelta:            make(map[string]int),
+		CommentMeanSimilarity: make(map[string]float64),
+		CommentMinSimilarity:  make(map[string]float64),
+		scoreCounts:           make(map[string]int),
+		commentCounts:         make(map[string]int),
+	}
+}
+
+// Add 累加一条回放结果
+func (s *Summary) Add(diff *Diff) {
+	s.Total++
+	if diff.Error != "" {
+		s.Failed++
+		return
+	}
+	if diff.Changed {
+		s.Changed++
+	}
+
+	for _, score := range diff.Scores {
+		name := stripIndexes(score.Name)
+		abs := math.Abs(score.Delta)
+		n := s.scoreCounts[name]
+		s.ScoreMeanAbsDelta[name] = (s.ScoreMeanAbsDelta[name]*float64(n) + abs) / float64(n+1)
+		s.ScoreMaxAbsDelta[name] = math.Max(s.ScoreMaxAbsDelta[name], abs)
+		s.scoreCounts[name] = n + 1
+	}
+
+	for _, count := range diff.Counts {
+		s.CountDelta[count.Type] += count.Delta
+	}
+
+	for _, comment := range diff.Comments {
+		name := stripIndexes(comment.Name)
+		n := s.commentCounts[name]
+		s.CommentMeanSimilarity[name] = (s.CommentMeanSimilarity[name]*float64(n) + comment.Similarity) / float64(n+1)
+		if n == 0 || comment.Similarity < s.CommentMinSimilarity[name] {
+			s.CommentMinSimilarity[name] = comment.Similarity
+		}
+		s.commentCounts[name] = n + 1
+	}
+}
+
+// ChangedScores 平均差异超过阈值的分数名称
+func (s *Summary) ChangedScores(threshold float64) []string {
+	var names []string
+	for name, delta := range s.ScoreMeanAbsDelta {
+		if delta > threshold {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stripIndexes 去掉数组下标，同一路径的各元素（如各段落评语、学情统计列表）合并统计
+func stripIndexes(name string) string {
+	var out []rune
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,185 @@
+package report
+
+import (
+	"essay-stateless/internal/model"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// 标注类型
+const (
+	AnnotationGoodWord  = "good_word" // 好词
+	AnnotationGrammar   = "grammar"   // 语法/标点/错别字
+	AnnotationPolishing = "polishing" // 润色修改
+)
+
+// Annotation 句内标注，Start/End 为句内字符（rune）偏移，左闭右开
+type Annotation struct {
+	Kind     string
+	Start    int
+	End      int
+	Category string // 语法错误类型或润色操作
+	Original string
+	Revised  string
+	Reason   string
+}
+
+// SentenceAnnotations 一个句子及其标注
+type SentenceAnnotations struct {
+	Text        string
+	IsGood      bool
+	GoodLabel   string
+	Annotations []Annotation
+}
+
+// AnnotationBuilder 将批改结果中的好句、好词、语法错误和润色修改映射到句内位置
+type AnnotationBuilder struct{}
+
+// NewAnnotationBuilder 创建标注构建器
+func NewAnnotationBuilder() *AnnotationBuilder {
+	return &AnnotationBuilder{}
+}
+
+// Build 按段落、句子构建标注
+func (b *AnnotationBuilder) Build(result *model.EvaluateResponse) [][]SentenceAnnotations {
+	sentenceEvals := result.AIEvaluation.WordSentenceEvaluation.SentenceEvaluations
+
+	polishingByParagraph := make(map[int][]model.PolishingEdit)
+	for _, pe := range result.AIEvaluation.PolishingEvaluation {
+		polishingByParagraph[pe.ParagraphIndex] = append(polishingByParagraph[pe.ParagraphIndex], pe.Edits...)
+	}
+
+	paragraphs := make([][]SentenceAnnotations, len(result.Text))
+	for pIndex, paragraph := range result.Text {
+		paragraphs[pIndex] = make([]SentenceAnnotations, len(paragraph))
+		for sIndex, sentence := range paragraph {
+			sa := SentenceAnnotations{Text: sentence}
+			length := utf8.RuneCountInString(sentence)
+
+			if pIndex < len(sentenceEvals) && sIndex < len(sentenceEvals[pIndex]) {
+				eval := sentenceEvals[pIndex][sIndex]
+				sa.IsGood = eval.IsGoodSentence
+				sa.GoodLabel = eval.Label
+
+				for _, we := range eval.WordEvaluations {
+					if ann, ok := b.fromWordEvaluation(we, length); ok {
+						sa.Annotations = append(sa.Annotations, ann)
+					}
+				}
+			}
+
+			for _, edit := range polishingByParagraph[pIndex] {
+				if edit.SentenceIndex != sIndex {
+					continue
+				}
+				if ann, ok := b.fromPolishingEdit(edit, sentence); ok {
+					sa.Annotations = append(sa.Annotations, ann)
+				}
+			}
+
+			sort.SliceStable(sa.Annotations, func(i, j int) bool {
+				return sa.Annotations[i].Start < sa.Annotations[j].Start
+			})
+			paragraphs[pIndex][sIndex] = sa
+		}
+	}
+
+	return paragraphs
+}
+
+// fromWordEvaluation 好词/语法错误标注
+func (b *AnnotationBuilder) fromWordEvaluation(we model.WordEvaluation, length int) (Annotation, bool) {
+	if len(we.Span) < 2 {
+		return Annotation{}, false
+	}
+
+	start, end := clamp(we.Span[0], 0, length), clamp(we.Span[1], 0, length)
+	if end <= start {
+		return Annotation{}, false
+	}
+
+	ann := Annotation{
+		Start:    start,
+		End:      end,
+		Category: we.Type["level2"],
+		Original: we.Ori,
+		Revised:  we.Revised,
+	}
+	switch we.Type["level1"] {
+	case "作文亮点":
+		ann.Kind = AnnotationGoodWord
+	case "还需努力":
+		ann.Kind = AnnotationGrammar
+	default:
+		return Annotation{}, false
+	}
+	return ann, true
+}
+
+// fromPolishingEdit 润色标注：按原文在句中的位置定位，找不到时退回使用Span（1起始、闭区间）
+func (b *AnnotationBuilder) fromPolishingEdit(edit model.PolishingEdit, sentence string) (Annotation, bool) {
+	length := utf8.RuneCountInString(sentence)
+
+	var start, end int
+	if idx := strings.Index(sentence, edit.Original); edit.Original != "" && idx >= 0 {
+		start = utf8.RuneCountInString(sentence[:idx])
+		end = start + utf8.RuneCountInString(edit.Original)
+	} else if len(edit.Span) >= 2 {
+		start, end = clamp(edit.Span[0]-1, 0, length), clamp(edit.Span[1], 0, length)
+	} else {
+		return Annotation{}, false
+	}
+	if end <= start {
+		return Annotation{}, false
+	}
+
+	return Annotation{
+		Kind:     AnnotationPolishing,
+		Start:    start,
+		End:      end,
+		Category: edit.Op,
+		Original: edit.Original,
+		Revised:  edit.Revised,
+		Reason:   edit.Reason,
+	}, true
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// DescribeAnnotation 标注的文字说明
+func DescribeAnnotation(ann Annotation) string {
+	switch ann.Kind {
+	case AnnotationGoodWord:
+		return "好词"
+	case AnnotationGrammar:
+		desc := ann.Category
+		if ann.Original != "" || ann.Revised != "" {
+			desc += "：" + ann.Original + " → " + ann.Revised
+		}
+		return desc
+	case AnnotationPolishing:
+		var desc string
+		switch ann.Category {
+		case "insert":
+			desc = "润色（在“" + ann.Original + "”后插入）：" + ann.Revised
+		case "delete":
+			desc = "润色（删除）：" + ann.Original
+		default:
+			desc = "润色（替换）：" + ann.Original + " → " + ann.Revised
+		}
+		if ann.Reason != "" {
+			desc += "。" + ann.Reason
+		}
+		return desc
+	}
+	return ""
+}
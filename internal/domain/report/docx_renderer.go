@@ -0,0 +1,359 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"essay-stateless/internal/model"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	docxAuthor   = "AI批改"
+	docxInitials = "AI"
+
+	wordNamespace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	relNamespace  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+// DOCXRenderer Word批改报告渲染器
+//
+// 直接生成OOXML并打包为zip，不依赖外部工具。语法错误和润色修改以修订（删除/插入）
+// 形式写入正文，并在批注中说明原因；好句与段落点评以批注形式挂在对应范围上，
+// 文末附评分表与总评。
+type DOCXRenderer struct {
+	annotation *AnnotationBuilder
+}
+
+// NewDOCXRenderer 创建Word报告渲染器
+func NewDOCXRenderer() *DOCXRenderer {
+	return &DOCXRenderer{
+		annotation: NewAnnotationBuilder(),
+	}
+}
+
+// docxWriter 生成document.xml正文与comments.xml批注
+type docxWriter struct {
+	body       strings.Builder
+	comments   strings.Builder
+	commentID  int
+	revisionID int
+	date       string
+}
+
+// Render 渲染Word报告
+func (r *DOCXRenderer) Render(w io.Writer, result *model.EvaluateResponse) error {
+	dw := &docxWriter{date: time.Now().UTC().Format(time.RFC3339)}
+
+	dw.paragraph("Title", "", dw.run(result.Title, ""))
+	info := fmt.Sprintf("文体：%s　年级：%d　字数：%d　段落：%d",
+		result.EssayInfo.EssayType, result.EssayInfo.Grade, result.EssayInfo.Counting.CharNum, len(result.Text))
+	dw.paragraph("Subtitle", "", dw.run(info, ""))
+
+	paragraphComments := make(map[int]string)
+	for _, pe := range result.AIEvaluation.ParagraphEvaluations {
+		paragraphComments[pe.ParagraphIndex] = pe.Comment
+	}
+
+	for pIndex, sentences := range r.annotation.Build(result) {
+		var content strings.Builder
+		paragraphComment := -1
+		if comment := paragraphComments[pIndex]; comment != "" {
+			paragraphComment = dw.addComment(fmt.Sprintf("第%d段点评：%s", pIndex+1, comment))
+			content.WriteString(commentRangeStart(paragraphComment))
+		}
+		for _, sa := range sentences {
+			content.WriteString(dw.sentence(sa))
+		}
+		if paragraphComment >= 0 {
+			content.WriteString(commentRangeEnd(paragraphComment))
+		}
+		dw.paragraph("", `<w:ind w:firstLineChars="200" w:firstLine="480"/>`, content.String())
+	}
+
+	dw.scoreSection(result)
+
+	return dw.pack(w)
+}
+
+// sentence 按标注边界切分句子：修订范围内的文字写为删除，修订结束处写入插入内容，
+// 每条语法/润色标注在其范围上挂一条批注
+func (dw *docxWriter) sentence(sa SentenceAnnotations) string {
+	var out strings.Builder
+	runes := []rune(sa.Text)
+
+	sentenceComment := -1
+	if sa.IsGood {
+		text := "好句"
+		if sa.GoodLabel != "" {
+			text += "：" + sa.GoodLabel
+		}
+		sentenceComment = dw.addComment(text)
+		out.WriteString(commentRangeStart(sentenceComment))
+	}
+
+	boundarySet := map[int]bool{0: true, len(runes): true}
+	for _, ann := range sa.Annotations {
+		boundarySet[ann.Start] = true
+		boundarySet[ann.End] = true
+	}
+	boundaries := make([]int, 0, len(boundarySet))
+	for b := range boundarySet {
+		boundaries = append(boundaries, b)
+	}
+	sort.Ints(boundaries)
+
+	tracked := selectTrackedChanges(sa.Annotations)
+	commentOf := make([]int, len(sa.Annotations))
+	for i, ann := range sa.Annotations {
+		commentOf[i] = -1
+		if ann.Kind != AnnotationGoodWord {
+			commentOf[i] = dw.addComment(DescribeAnnotation(ann))
+		}
+	}
+
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		text := string(runes[start:end])
+
+		for j, ann := range sa.Annotations {
+			if ann.Start == start && commentOf[j] >= 0 {
+				out.WriteString(commentRangeStart(commentOf[j]))
+			}
+		}
+
+		deleted, goodWord := false, false
+		for j, ann := range sa.Annotations {
+			if ann.Start > start || end > ann.End {
+				continue
+			}
+			if ann.Kind == AnnotationGoodWord {
+				goodWord = true
+			}
+			if tracked[j] && ann.Category != "insert" {
+				deleted = true
+			}
+		}
+
+		rPr := ""
+		if goodWord {
+			rPr = `<w:b/><w:color w:val="2E7D32"/>`
+		}
+		if deleted {
+			out.WriteString(dw.deletion(text, rPr))
+		} else {
+			out.WriteString(dw.run(text, rPr))
+		}
+
+		for j, ann := range sa.Annotations {
+			if ann.End == end && tracked[j] && ann.Revised != "" && ann.Category != "delete" {
+				out.WriteString(dw.insertion(ann.Revised))
+			}
+		}
+		for j, ann := range sa.Annotations {
+			if ann.End == end && commentOf[j] >= 0 {
+				out.WriteString(commentRangeEnd(commentOf[j]))
+			}
+		}
+	}
+
+	if sentenceComment >= 0 {
+		out.WriteString(commentRangeEnd(sentenceComment))
+	}
+	return out.String()
+}
+
+// selectTrackedChanges 挑选可写成修订的标注：Word修订不能相互重叠，按起始位置依次
+// 选取不重叠的修改，其余修改只保留批注
+func selectTrackedChanges(annotations []Annotation) []bool {
+	tracked := make([]bool, len(annotations))
+	lastEnd := -1
+	for i, ann := range annotations {
+		switch ann.Kind {
+		case AnnotationGrammar:
+			if ann.Revised == "" {
+				continue
+			}
+		case AnnotationPolishing:
+			if ann.Revised == "" && ann.Category != "delete" {
+				continue
+			}
+		default:
+			continue
+		}
+		if ann.Start < lastEnd {
+			continue
+		}
+		tracked[i] = true
+		lastEnd = ann.End
+	}
+	return tracked
+}
+
+// scoreSection 文末评分表与总评
+func (dw *docxWriter) scoreSection(result *model.EvaluateResponse) {
+	dw.paragraph("Heading1", "", dw.run("评分", ""))
+
+	var table strings.Builder
+	table.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(&table, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="999999"/>`, side)
+	}
+	table.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid><w:gridCol w:w="1400"/><w:gridCol w:w="1400"/><w:gridCol w:w="6200"/></w:tblGrid>`)
+
+	row := func(bold bool, cells ...string) {
+		rPr := ""
+		if bold {
+			rPr = "<w:b/>"
+		}
+		table.WriteString("<w:tr>")
+		for _, cell := range cells {
+			fmt.Fprintf(&table, `<w:tc><w:p>%s</w:p></w:tc>`, dw.run(cell, rPr))
+		}
+		table.WriteString("</w:tr>")
+	}
+	row(true, "维度", "得分", "评语")
+	for _, sr := range BuildScoreRows(result) {
+		row(sr.Name == "总分", sr.Name, sr.Score, sr.Comment)
+	}
+	table.WriteString("</w:tbl>")
+	dw.body.WriteString(table.String())
+
+	ai := result.AIEvaluation
+	sections := []struct{ title, text string }{
+		{"总评", ai.OverallEvaluation.Description},
+		{"评分说明", ai.ScoreEvaluation.Comment},
+		{"修改建议", ai.SuggestionEvaluation.SuggestionDescription},
+	}
+	for _, s := range sections {
+		if s.text == "" {
+			continue
+		}
+		dw.paragraph("Heading1", "", dw.run(s.title, ""))
+		dw.paragraph("", "", dw.run(s.text, ""))
+	}
+
+	if mv := ai.ModelVersion; mv.Name != "" {
+		dw.paragraph("Subtitle", "", dw.run("模型版本："+strings.TrimSpace(mv.Name+" "+mv.Version), ""))
+	}
+}
+
+func (dw *docxWriter) paragraph(style, pPr, content string) {
+	dw.body.WriteString("<w:p>")
+	if style != "" || pPr != "" {
+		dw.body.WriteString("<w:pPr>")
+		if style != "" {
+			fmt.Fprintf(&dw.body, `<w:pStyle w:val="%s"/>`, style)
+		}
+		dw.body.WriteString(pPr)
+		dw.body.WriteString("</w:pPr>")
+	}
+	dw.body.WriteString(content)
+	dw.body.WriteString("</w:p>")
+}
+
+func (dw *docxWriter) run(text, rPr string) string {
+	if text == "" {
+		return ""
+	}
+	if rPr != "" {
+		rPr = "<w:rPr>" + rPr + "</w:rPr>"
+	}
+	return fmt.Sprintf(`<w:r>%s<w:t xml:space="preserve">%s</w:t></w:r>`, rPr, escapeXML(text))
+}
+
+func (dw *docxWriter) deletion(text, rPr string) string {
+	dw.revisionID++
+	if rPr != "" {
+		rPr = "<w:rPr>" + rPr + "</w:rPr>"
+	}
+	return fmt.Sprintf(`<w:del w:id="%d" w:author="%s" w:date="%s"><w:r>%s<w:delText xml:space="preserve">%s</w:delText></w:r></w:del>`,
+		dw.revisionID, docxAuthor, dw.date, rPr, escapeXML(text))
+}
+
+func (dw *docxWriter) insertion(text string) string {
+	dw.revisionID++
+	return fmt.Sprintf(`<w:ins w:id="%d" w:author="%s" w:date="%s">%s</w:ins>`,
+		dw.revisionID, docxAuthor, dw.date, dw.run(text, ""))
+}
+
+// addComment 写入一条批注，返回批注ID
+func (dw *docxWriter) addComment(text string) int {
+	id := dw.commentID
+	dw.commentID++
+	fmt.Fprintf(&dw.comments, `<w:comment w:id="%d" w:author="%s" w:date="%s" w:initials="%s"><w:p><w:pPr><w:pStyle w:val="CommentText"/></w:pPr>%s</w:p></w:comment>`,
+		id, docxAuthor, dw.date, docxInitials, dw.run(text, ""))
+	return id
+}
+
+func commentRangeStart(id int) string {
+	return fmt.Sprintf(`<w:commentRangeStart w:id="%d"/>`, id)
+}
+
+func commentRangeEnd(id int) string {
+	return fmt.Sprintf(`<w:commentRangeEnd w:id="%d"/><w:r><w:rPr><w:rStyle w:val="CommentReference"/></w:rPr><w:commentReference w:id="%d"/></w:r>`, id, id)
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// pack 打包为docx
+func (dw *docxWriter) pack(w io.Writer) error {
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRootRels},
+		{"word/_rels/document.xml.rels", docxDocumentRels},
+		{"word/styles.xml", docxStyles},
+		{"word/document.xml", xml.Header + `<w:document xmlns:w="` + wordNamespace + `" xmlns:r="` + relNamespace + `"><w:body>` +
+			dw.body.String() +
+			`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1440" w:right="1800" w:bottom="1440" w:left="1800" w:header="851" w:footer="992" w:gutter="0"/></w:sectPr></w:body></w:document>`},
+		{"word/comments.xml", xml.Header + `<w:comments xmlns:w="` + wordNamespace + `">` + dw.comments.String() + `</w:comments>`},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("写入%s失败: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("写入%s失败: %w", f.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+const docxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/word/comments.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.comments+xml"/>` +
+	`</Types>`
+
+const docxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`</Relationships>`
+
+const docxDocumentRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments" Target="comments.xml"/>` +
+	`</Relationships>`
+
+const docxStyles = xml.Header + `<w:styles xmlns:w="` + wordNamespace + `">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Times New Roman" w:hAnsi="Times New Roman" w:eastAsia="宋体"/><w:sz w:val="24"/><w:szCs w:val="24"/><w:lang w:eastAsia="zh-CN"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="120" w:line="360" w:lineRule="auto"/><w:jc w:val="both"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:pPr><w:jc w:val="center"/><w:spacing w:after="240"/></w:pPr><w:rPr><w:rFonts w:eastAsia="黑体"/><w:b/><w:sz w:val="36"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:pPr><w:jc w:val="center"/></w:pPr><w:rPr><w:color w:val="666666"/><w:sz w:val="20"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="240"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:rFonts w:eastAsia="黑体"/><w:b/><w:sz w:val="28"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="CommentText"><w:name w:val="annotation text"/><w:basedOn w:val="Normal"/><w:rPr><w:sz w:val="20"/></w:rPr></w:style>` +
+	`<w:style w:type="character" w:styleId="CommentReference"><w:name w:val="annotation reference"/><w:rPr><w:sz w:val="16"/></w:rPr></w:style>` +
+	`</w:styles>`
@@ -0,0 +1,219 @@
+package report
+
+import (
+	"embed"
+	"essay-stateless/internal/model"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed templates/report.html.tmpl templates/report.css
+var templateFS embed.FS
+
+// HTMLRenderer 可打印的HTML批改报告渲染器
+type HTMLRenderer struct {
+	tmpl       *template.Template
+	css        template.CSS
+	annotation *AnnotationBuilder
+}
+
+// NewHTMLRenderer 创建HTML报告渲染器
+func NewHTMLRenderer() *HTMLRenderer {
+	css, err := templateFS.ReadFile("templates/report.css")
+	if err != nil {
+		panic(fmt.Sprintf("读取报告样式失败: %v", err))
+	}
+
+	return &HTMLRenderer{
+		tmpl:       template.Must(template.ParseFS(templateFS, "templates/report.html.tmpl")),
+		css:        template.CSS(css),
+		annotation: NewAnnotationBuilder(),
+	}
+}
+
+type htmlReportView struct {
+	CSS          template.CSS
+	Title        string
+	EssayType    string
+	Grade        int
+	CharNum      int
+	ParaNum      int
+	Paragraphs   []htmlParagraphView
+	ScoreRows    []ScoreRow
+	Overall      string
+	ScoreComment string
+	Suggestion   string
+	ModelVersion string
+	GeneratedAt  string
+}
+
+type htmlParagraphView struct {
+	Number    int
+	Sentences []htmlSentenceView
+	Comment   string
+	Notes     []htmlNoteView
+}
+
+type htmlSentenceView struct {
+	IsGood    bool
+	GoodLabel string
+	Segments  []htmlSegmentView
+}
+
+type htmlSegmentView struct {
+	Text  string
+	Class string
+	Notes []int
+}
+
+type htmlNoteView struct {
+	Index int
+	Text  string
+}
+
+// Render 渲染HTML报告
+func (r *HTMLRenderer) Render(w io.Writer, result *model.EvaluateResponse) error {
+	view := htmlReportView{
+		CSS:          r.css,
+		Title:        result.Title,
+		EssayType:    result.EssayInfo.EssayType,
+		Grade:        result.EssayInfo.Grade,
+		CharNum:      result.EssayInfo.Counting.CharNum,
+		ParaNum:      len(result.Text),
+		ScoreRows:    BuildScoreRows(result),
+		Overall:      result.AIEvaluation.OverallEvaluation.Description,
+		ScoreComment: result.AIEvaluation.ScoreEvaluation.Comment,
+		Suggestion:   result.AIEvaluation.SuggestionEvaluation.SuggestionDescription,
+		GeneratedAt:  time.Now().Format("2006-01-02 15:04"),
+	}
+	if mv := result.AIEvaluation.ModelVersion; mv.Name != "" {
+		view.ModelVersion = strings.TrimSpace(mv.Name + " " + mv.Version)
+	}
+
+	paragraphComments := make(map[int]string)
+	for _, pe := range result.AIEvaluation.ParagraphEvaluations {
+		paragraphComments[pe.ParagraphIndex] = pe.Comment
+	}
+
+	noteIndex := 0
+	for pIndex, sentences := range r.annotation.Build(result) {
+		paragraph := htmlParagraphView{
+			Number:  pIndex + 1,
+			Comment: paragraphComments[pIndex],
+		}
+		for _, sa := range sentences {
+			sentence := htmlSentenceView{IsGood: sa.IsGood, GoodLabel: sa.GoodLabel}
+			sentence.Segments, paragraph.Notes = r.buildSegments(sa, paragraph.Notes, &noteIndex)
+			paragraph.Sentences = append(paragraph.Sentences, sentence)
+		}
+		view.Paragraphs = append(view.Paragraphs, paragraph)
+	}
+
+	return r.tmpl.Execute(w, view)
+}
+
+// buildSegments 按标注边界切分句子，每段带上覆盖它的标注样式，标注结束处附加脚注编号
+func (r *HTMLRenderer) buildSegments(sa SentenceAnnotations, notes []htmlNoteView, noteIndex *int) ([]htmlSegmentView, []htmlNoteView) {
+	runes := []rune(sa.Text)
+	if len(sa.Annotations) == 0 {
+		return []htmlSegmentView{{Text: sa.Text}}, notes
+	}
+
+	boundarySet := map[int]bool{0: true, len(runes): true}
+	for _, ann := range sa.Annotations {
+		boundarySet[ann.Start] = true
+		boundarySet[ann.End] = true
+	}
+	boundaries := make([]int, 0, len(boundarySet))
+	for b := range boundarySet {
+		boundaries = append(boundaries, b)
+	}
+	sort.Ints(boundaries)
+
+	// 为需要说明的标注分配脚注编号（好词无需说明）
+	noteOf := make([]int, len(sa.Annotations))
+	for i, ann := range sa.Annotations {
+		if ann.Kind == AnnotationGoodWord {
+			continue
+		}
+		*noteIndex++
+		noteOf[i] = *noteIndex
+		notes = append(notes, htmlNoteView{Index: *noteIndex, Text: DescribeAnnotation(ann)})
+	}
+
+	var segments []htmlSegmentView
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		segment := htmlSegmentView{Text: string(runes[start:end])}
+
+		var classes []string
+		for j, ann := range sa.Annotations {
+			if ann.Start <= start && end <= ann.End {
+				classes = append(classes, cssClass(ann.Kind))
+			}
+			if ann.End == end && noteOf[j] > 0 {
+				segment.Notes = append(segment.Notes, noteOf[j])
+			}
+		}
+		segment.Class = strings.Join(uniqueStrings(classes), " ")
+		segments = append(segments, segment)
+	}
+
+	return segments, notes
+}
+
+func cssClass(kind string) string {
+	return strings.ReplaceAll(kind, "_", "-")
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// ScoreRow 评分表的一行
+type ScoreRow struct {
+	Name    string
+	Score   string
+	Comment string
+}
+
+// BuildScoreRows 构建评分表：各维度得分（有分项总分时显示“得分/总分”）及评语，最后一行为总分
+func BuildScoreRows(result *model.EvaluateResponse) []ScoreRow {
+	se := result.AIEvaluation.ScoreEvaluation
+	scores := se.Scores
+
+	format := func(score int64, withTotal string) string {
+		if withTotal != "" {
+			return withTotal
+		}
+		return fmt.Sprintf("%d", score)
+	}
+
+	var rows []ScoreRow
+	add := func(name string, score int64, withTotal, comment string) {
+		if score == 0 && withTotal == "" && comment == "" {
+			return
+		}
+		rows = append(rows, ScoreRow{Name: name, Score: format(score, withTotal), Comment: comment})
+	}
+	add("内容", scores.Content, scores.ContentWithTotal, se.Comments.Content)
+	add("表达", scores.Expression, scores.ExpressionWithTotal, se.Comments.Expression)
+	add("结构", scores.Structure, scores.StructureWithTotal, se.Comments.Structure)
+	add("发展", scores.Development, scores.DevelopmentWithTotal, se.Comments.Development)
+	add("书写", scores.Appearance, scores.AppearanceWithTotal, se.Comments.Appearance)
+
+	rows = append(rows, ScoreRow{Name: "总分", Score: format(scores.All, scores.AllWithTotal)})
+	return rows
+}
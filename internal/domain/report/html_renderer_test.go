@@ -0,0 +1,50 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"essay-stateless/internal/model"
+)
+
+func TestBuildScoreRows(t *testing.T) {
+	tests := []struct {
+		name     string
+		scores   model.Scores
+		comments model.Comments
+		want     []ScoreRow
+	}{
+		{
+			name:   "带满分的各项",
+			scores: model.Scores{All: 42, Content: 18, ContentWithTotal: "18/20", AllWithTotal: "42/50"},
+			want:   []ScoreRow{{Name: "内容", Score: "18/20"}, {Name: "总分", Score: "42/50"}},
+		},
+		{
+			name:     "卷面分带满分",
+			scores:   model.Scores{All: 46, Appearance: 4, AppearanceWithTotal: "4/5", AllWithTotal: "46/55"},
+			comments: model.Comments{Appearance: "字迹工整"},
+			want:     []ScoreRow{{Name: "书写", Score: "4/5", Comment: "字迹工整"}, {Name: "总分", Score: "46/55"}},
+		},
+		{
+			name:   "未知满分时只给分数",
+			scores: model.Scores{All: 30, Structure: 8},
+			want:   []ScoreRow{{Name: "结构", Score: "8"}, {Name: "总分", Score: "30"}},
+		},
+		{
+			name:   "没有卷面分时不列书写",
+			scores: model.Scores{All: 30},
+			want:   []ScoreRow{{Name: "总分", Score: "30"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &model.EvaluateResponse{}
+			result.AIEvaluation.ScoreEvaluation.Scores = tt.scores
+			result.AIEvaluation.ScoreEvaluation.Comments = tt.comments
+			if got := BuildScoreRows(result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildScoreRows = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package rubric
+
+import (
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// 评分维度
+const (
+	DimensionContent     = "content"
+	DimensionExpression  = "expression"
+	DimensionStructure   = "structure"
+	DimensionDevelopment = "development"
+	DimensionAppearance  = "appearance"
+)
+
+// dimensionNames 维度中文名
+var dimensionNames = map[string]string{
+	DimensionContent:     "内容",
+	DimensionExpression:  "表达",
+	DimensionStructure:   "结构",
+	DimensionDevelopment: "发展",
+	DimensionAppearance:  "卷面",
+}
+
+// Totals 总分及各维度满分，0表示未指定
+type Totals struct {
+	All        int64
+	Dimensions map[string]int64
+}
+
+// BandClassifier 按考试评分标准给作文分类（一类文~五类文、基础等级/发展等级等）
+//
+// 类别规则全部来自配置：先按年级选出适用的标准，再按得分率从高到低匹配第一个达到下限的类别。
+type BandClassifier struct {
+	cfg config.ExamBandConfig
+}
+
+// NewBandClassifier 创建分类器，未配置任何标准时返回nil
+func NewBandClassifier(cfg config.ExamBandConfig) *BandClassifier {
+	if len(cfg.Standards) == 0 {
+		return nil
+	}
+
+	// 配置中类别顺序不一定从高到低，复制后按下限排序，匹配时取第一个达到下限的
+	standards := make([]config.ExamStandardConfig, len(cfg.Standards))
+	for i, standard := range cfg.Standards {
+		standard.Bands = sortedRules(standard.Bands)
+		dimensions := make([]config.DimensionBandConfig, len(standard.Dimensions))
+		for j, dimension := range standard.Dimensions {
+			dimension.Levels = sortedRules(dimension.Levels)
+			dimensions[j] = dimension
+		}
+		standard.Dimensions = dimensions
+		standards[i] = standard
+	}
+	cfg.Standards = standards
+	return &BandClassifier{cfg: cfg}
+}
+
+func sortedRules(rules []config.BandRuleConfig) []config.BandRuleConfig {
+	sorted := append([]config.BandRuleConfig(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].MinRatio > sorted[j].MinRatio })
+	return sorted
+}
+
+// Classify 按年级对应的标准分类，没有适用标准时返回nil
+func (c *BandClassifier) Classify(grade int, scores model.Scores, totals Totals) *model.ExamBandEvaluation {
+	if c == nil {
+		return nil
+	}
+	standard := c.standardFor(grade)
+	if standard == nil {
+		return nil
+	}
+
+	dimensionScores := DimensionScores(scores)
+	dimensionTotals := effectiveTotals(standard, totals)
+	evaluation := &model.ExamBandEvaluation{
+		Standard:     standard.ID,
+		StandardName: standard.Name,
+	}
+
+	if len(standard.Bands) > 0 && totals.All > 0 {
+		band := classify(standard.Bands, "总分", scores.All, totals.All)
+		if weakest := weakestDimension(dimensionScores, dimensionTotals); weakest != "" {
+			band.Reasons = append(band.Reasons, weakest)
+		}
+		evaluation.Band = band
+	}
+
+	for _, dimension := range standard.Dimensions {
+		total := dimensionTotals[dimension.Dimension]
+		if total <= 0 || len(dimension.Levels) == 0 {
+			continue
+		}
+		label := dimensionName(dimension.Dimension)
+		if dimension.Group != "" {
+			label = dimension.Group + "-" + label
+		}
+		evaluation.Dimensions = append(evaluation.Dimensions, model.DimensionBandResult{
+			Dimension:  dimension.Dimension,
+			Group:      dimension.Group,
+			BandResult: *classify(dimension.Levels, label, dimensionScores[dimension.Dimension], total),
+		})
+	}
+
+	if evaluation.Band == nil && len(evaluation.Dimensions) == 0 {
+		return nil
+	}
+	return evaluation
+}
+
+// effectiveTotals 各维度满分：请求指定的优先，否则使用标准中配置的满分
+func effectiveTotals(standard *config.ExamStandardConfig, totals Totals) map[string]int64 {
+	effective := make(map[string]int64)
+	for _, dimension := range standard.Dimensions {
+		if dimension.Total > 0 {
+			effective[dimension.Dimension] = dimension.Total
+		}
+	}
+	for dimension, total := range totals.Dimensions {
+		if total > 0 {
+			effective[dimension] = total
+		}
+	}
+	return effective
+}
+
+// standardFor 取第一个覆盖该年级的标准，年级未知或无匹配时使用默认标准
+func (c *BandClassifier) standardFor(grade int) *config.ExamStandardConfig {
+	if grade > 0 {
+		for i := range c.cfg.Standards {
+			standard := &c.cfg.Standards[i]
+			if (standard.MinGrade == 0 || grade >= standard.MinGrade) && (standard.MaxGrade == 0 || grade <= standard.MaxGrade) {
+				return standard
+			}
+		}
+	}
+	for i := range c.cfg.Standards {
+		if c.cfg.Standards[i].ID == c.cfg.Default {
+			return &c.cfg.Standards[i]
+		}
+	}
+	return nil
+}
+
+// classify 按得分率从高到低匹配第一个达到下限的类别，都未达到时归入最低类别
+func classify(rules []config.BandRuleConfig, label string, score, total int64) *model.BandResult {
+	ratio := ratioOf(score, total)
+	result := &model.BandResult{Score: score, Total: total, Ratio: roundRatio(ratio)}
+
+	matched := len(rules) - 1
+	for i, rule := range rules {
+		if reaches(ratio, rule.MinRatio) {
+			matched = i
+			break
+		}
+	}
+	rule := rules[matched]
+	result.Name = rule.Name
+	result.Descriptor = rule.Descriptor
+
+	if reaches(ratio, rule.MinRatio) {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s %d/%d，得分率 %s，达到%s下限 %s",
+			label, score, total, percent(ratio), rule.Name, percent(rule.MinRatio)))
+	} else {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s %d/%d，得分率 %s，低于所有类别下限",
+			label, score, total, percent(ratio)))
+	}
+	if matched > 0 {
+		higher := rules[matched-1]
+		need := int64(math.Ceil(higher.MinRatio*float64(total)-ratioEpsilon)) - score
+		if need > 0 {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("距%s还差 %d 分", higher.Name, need))
+		}
+	}
+	return result
+}
+
+// weakestDimension 得分率最低的维度，作为总分类别的补充依据
+func weakestDimension(scores, totals map[string]int64) string {
+	weakest, weakestRatio := "", 2.0
+	for _, dimension := range []string{DimensionContent, DimensionExpression, DimensionStructure, DimensionDevelopment} {
+		if totals[dimension] <= 0 {
+			continue
+		}
+		if ratio := ratioOf(scores[dimension], totals[dimension]); ratio < weakestRatio {
+			weakest, weakestRatio = dimension, ratio
+		}
+	}
+	if weakest == "" {
+		return ""
+	}
+	return fmt.Sprintf("主要失分维度：%s（得分率 %s）", dimensionName(weakest), percent(weakestRatio))
+}
+
+// DimensionScores 各维度得分
+func DimensionScores(scores model.Scores) map[string]int64 {
+	return map[string]int64{
+		DimensionContent:     scores.Content,
+		DimensionExpression:  scores.Expression,
+		DimensionStructure:   scores.Structure,
+		DimensionDevelopment: scores.Development,
+		DimensionAppearance:  scores.Appearance,
+	}
+}
+
+func dimensionName(dimension string) string {
+	if name, ok := dimensionNames[dimension]; ok {
+		return name
+	}
+	return dimension
+}
+
+// ratioEpsilon 消除 0.55*20 这类浮点误差对边界判断的影响
+const ratioEpsilon = 1e-9
+
+func reaches(ratio, minRatio float64) bool {
+	return ratio+ratioEpsilon >= minRatio
+}
+
+func ratioOf(score, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(score) / float64(total)
+}
+
+func roundRatio(ratio float64) float64 {
+	return math.Round(ratio*1000) / 1000
+}
+
+func percent(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", ratio*100)
+}
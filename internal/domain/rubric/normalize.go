@@ -0,0 +1,187 @@
+package rubric
+
+import (
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"fmt"
+	"math"
+)
+
+// 总分与各维度之和不一致时的处理策略
+const (
+	TotalPolicySum   = "sum"   // 总分取各维度之和
+	TotalPolicyScale = "scale" // 各维度按总分等比调整
+	TotalPolicyKeep  = "keep"  // 保留上游分数，只记录不一致
+)
+
+// 分数校正类型
+const (
+	AdjustmentRescale   = "rescale"
+	AdjustmentClamp     = "clamp"
+	AdjustmentReconcile = "reconcile"
+	AdjustmentMismatch  = "mismatch"
+)
+
+// 校正字段名，all表示总分
+const fieldAll = "all"
+
+// FieldName 分数字段的中文名
+func FieldName(field string) string {
+	if field == fieldAll {
+		return "总分"
+	}
+	return dimensionName(field)
+}
+
+// ScoreNormalizer 校验并校正上游分数
+//
+// 依次进行：上游固定分制折算到请求满分、越界截断到 0~满分、总分与各维度之和对齐（各维度满分之和等于总分时才对齐）。
+// 每次校正都会记录原值、新值和原因。
+type ScoreNormalizer struct {
+	cfg config.ScoreNormalizationConfig
+}
+
+// NewScoreNormalizer 创建分数校正器，未启用时返回nil
+func NewScoreNormalizer(cfg config.ScoreNormalizationConfig) *ScoreNormalizer {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &ScoreNormalizer{cfg: cfg}
+}
+
+// UpstreamTotals 上游打分所用的满分：配置了固定分制的项取其分制，其余取请求满分；未启用时原样返回
+//
+// 集成评分在校正之前比较各次原始分数的分歧，需按上游分制判断。
+func (n *ScoreNormalizer) UpstreamTotals(totals Totals) Totals {
+	if n == nil {
+		return totals
+	}
+	scale := n.cfg.UpstreamScale
+	result := Totals{All: totals.All, Dimensions: make(map[string]int64, len(totals.Dimensions))}
+	if scale.All > 0 {
+		result.All = scale.All
+	}
+	for dimension, total := range totals.Dimensions {
+		result.Dimensions[dimension] = total
+	}
+	for dimension, value := range map[string]int64{
+		DimensionContent:     scale.Content,
+		DimensionExpression:  scale.Expression,
+		DimensionStructure:   scale.Structure,
+		DimensionDevelopment: scale.Development,
+		DimensionAppearance:  scale.Appearance,
+	} {
+		if value > 0 {
+			result.Dimensions[dimension] = value
+		}
+	}
+	return result
+}
+
+// Normalize 就地校正分数，返回所做的校正
+func (n *ScoreNormalizer) Normalize(scores *model.Scores, totals Totals) []model.ScoreAdjustment {
+	if n == nil || scores == nil {
+		return nil
+	}
+
+	var adjustments []model.ScoreAdjustment
+	record := func(field, kind string, value *int64, adjusted int64, reason string) {
+		if *value == adjusted && kind != AdjustmentMismatch {
+			return
+		}
+		adjustments = append(adjustments, model.ScoreAdjustment{
+			Field:    field,
+			Kind:     kind,
+			Original: *value,
+			Adjusted: adjusted,
+			Reason:   reason,
+		})
+		*value = adjusted
+	}
+
+	fields := []struct {
+		name  string
+		value *int64
+		scale int64
+		total int64
+	}{
+		{fieldAll, &scores.All, n.cfg.UpstreamScale.All, totals.All},
+		{DimensionContent, &scores.Content, n.cfg.UpstreamScale.Content, totals.Dimensions[DimensionContent]},
+		{DimensionExpression, &scores.Expression, n.cfg.UpstreamScale.Expression, totals.Dimensions[DimensionExpression]},
+		{DimensionStructure, &scores.Structure, n.cfg.UpstreamScale.Structure, totals.Dimensions[DimensionStructure]},
+		{DimensionDevelopment, &scores.Development, n.cfg.UpstreamScale.Development, totals.Dimensions[DimensionDevelopment]},
+		{DimensionAppearance, &scores.Appearance, n.cfg.UpstreamScale.Appearance, totals.Dimensions[DimensionAppearance]},
+	}
+
+	for _, field := range fields {
+		if field.scale > 0 && field.total > 0 && field.scale != field.total {
+			rescaled := int64(math.Round(float64(*field.value) * float64(field.total) / float64(field.scale)))
+			record(field.name, AdjustmentRescale, field.value, rescaled,
+				fmt.Sprintf("上游按 %d 分制打分，折算为 %d 分制", field.scale, field.total))
+		}
+		if *field.value < 0 {
+			record(field.name, AdjustmentClamp, field.value, 0, "分数为负，按0分计")
+		} else if field.total > 0 && *field.value > field.total {
+			record(field.name, AdjustmentClamp, field.value, field.total, fmt.Sprintf("超出满分 %d，按满分计", field.total))
+		}
+	}
+
+	// 只有各维度满分之和恰好等于总分时，总分才应等于各维度之和
+	var dimensions []int
+	var totalSum, scoreSum int64
+	for i, field := range fields[1:] {
+		if field.total > 0 {
+			dimensions = append(dimensions, i+1)
+			totalSum += field.total
+			scoreSum += *field.value
+		}
+	}
+	if len(dimensions) == 0 || totalSum != totals.All || scoreSum == scores.All {
+		return adjustments
+	}
+
+	switch n.cfg.TotalPolicy {
+	case TotalPolicyScale:
+		current := make([]int64, len(dimensions))
+		limits := make([]int64, len(dimensions))
+		for j, i := range dimensions {
+			current[j] = *fields[i].value
+			limits[j] = fields[i].total
+		}
+		for j, adjusted := range rebalance(current, limits, scores.All) {
+			field := fields[dimensions[j]]
+			record(field.name, AdjustmentReconcile, field.value, adjusted,
+				fmt.Sprintf("各维度之和 %d 与总分 %d 不一致，按总分等比调整", scoreSum, scores.All))
+		}
+	case TotalPolicyKeep:
+		record(fieldAll, AdjustmentMismatch, &scores.All, scores.All,
+			fmt.Sprintf("总分 %d 与各维度之和 %d 不一致，按配置保留上游分数", scores.All, scoreSum))
+	default:
+		record(fieldAll, AdjustmentReconcile, &scores.All, scoreSum,
+			fmt.Sprintf("总分 %d 与各维度之和 %d 不一致，总分取各维度之和", scores.All, scoreSum))
+	}
+	return adjustments
+}
+
+// rebalance 把各维度分数调整为和等于target：下调时按当前分数比例分摊，上调时按距满分的余量比例分摊
+func rebalance(current, limits []int64, target int64) []int64 {
+	var sum, capacity int64
+	for i := range current {
+		sum += current[i]
+		capacity += limits[i]
+	}
+	target = min(max(target, 0), capacity)
+
+	if target <= sum {
+		return apportion(target, current)
+	}
+	headroom := make([]int64, len(current))
+	for i := range current {
+		headroom[i] = limits[i] - current[i]
+	}
+	result := make([]int64, len(current))
+	for i, extra := range apportion(target-sum, headroom) {
+		result[i] = current[i] + extra
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package rubric
+
+import (
+	"reflect"
+	"testing"
+
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+)
+
+func TestScoreNormalizer(t *testing.T) {
+	// 各维度满分之和等于总分
+	fullTotals := Totals{All: 50, Dimensions: map[string]int64{
+		DimensionContent: 20, DimensionExpression: 15, DimensionStructure: 10, DimensionDevelopment: 5,
+	}}
+	type adjustment struct {
+		field, kind        string
+		original, adjusted int64
+	}
+	tests := []struct {
+		name   string
+		cfg    config.ScoreNormalizationConfig
+		scores model.Scores
+		totals Totals
+		want   model.Scores
+		adjust []adjustment
+	}{
+		{
+			name:   "无需校正",
+			scores: model.Scores{All: 42, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			totals: fullTotals,
+			want:   model.Scores{All: 42, Content: 18, Expression: 12, Structure: 8, Development: 4},
+		},
+		{
+			name:   "分制折算",
+			cfg:    config.ScoreNormalizationConfig{UpstreamScale: config.ScoreScaleConfig{All: 100}},
+			scores: model.Scores{All: 85},
+			totals: Totals{All: 50},
+			want:   model.Scores{All: 43},
+			adjust: []adjustment{{fieldAll, AdjustmentRescale, 85, 43}},
+		},
+		{
+			name:   "超出满分截断",
+			scores: model.Scores{All: 55},
+			totals: Totals{All: 50},
+			want:   model.Scores{All: 50},
+			adjust: []adjustment{{fieldAll, AdjustmentClamp, 55, 50}},
+		},
+		{
+			name:   "负分按0分",
+			scores: model.Scores{All: 30, Content: -2},
+			totals: Totals{All: 50, Dimensions: map[string]int64{DimensionContent: 20}},
+			want:   model.Scores{All: 30},
+			adjust: []adjustment{{DimensionContent, AdjustmentClamp, -2, 0}},
+		},
+		{
+			name:   "总分取各维度之和",
+			scores: model.Scores{All: 40, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			totals: fullTotals,
+			want:   model.Scores{All: 42, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			adjust: []adjustment{{fieldAll, AdjustmentReconcile, 40, 42}},
+		},
+		{
+			name:   "各维度按总分等比下调",
+			cfg:    config.ScoreNormalizationConfig{TotalPolicy: TotalPolicyScale},
+			scores: model.Scores{All: 40, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			totals: fullTotals,
+			want:   model.Scores{All: 40, Content: 17, Expression: 11, Structure: 8, Development: 4},
+			adjust: []adjustment{{DimensionContent, AdjustmentReconcile, 18, 17}, {DimensionExpression, AdjustmentReconcile, 12, 11}},
+		},
+		{
+			name:   "各维度按余量等比上调",
+			cfg:    config.ScoreNormalizationConfig{TotalPolicy: TotalPolicyScale},
+			scores: model.Scores{All: 45, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			totals: fullTotals,
+			want:   model.Scores{All: 45, Content: 19, Expression: 13, Structure: 9, Development: 4},
+			adjust: []adjustment{
+				{DimensionContent, AdjustmentReconcile, 18, 19},
+				{DimensionExpression, AdjustmentReconcile, 12, 13},
+				{DimensionStructure, AdjustmentReconcile, 8, 9},
+			},
+		},
+		{
+			name:   "保留上游分数只记录",
+			cfg:    config.ScoreNormalizationConfig{TotalPolicy: TotalPolicyKeep},
+			scores: model.Scores{All: 40, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			totals: fullTotals,
+			want:   model.Scores{All: 40, Content: 18, Expression: 12, Structure: 8, Development: 4},
+			adjust: []adjustment{{fieldAll, AdjustmentMismatch, 40, 40}},
+		},
+		{
+			name:   "维度满分之和不等于总分时不对齐",
+			scores: model.Scores{All: 40, Content: 18},
+			totals: Totals{All: 50, Dimensions: map[string]int64{DimensionContent: 20}},
+			want:   model.Scores{All: 40, Content: 18},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Enabled = true
+			scores := tt.scores
+			var got []adjustment
+			for _, a := range NewScoreNormalizer(tt.cfg).Normalize(&scores, tt.totals) {
+				got = append(got, adjustment{a.Field, a.Kind, a.Original, a.Adjusted})
+			}
+			if scores != tt.want {
+				t.Errorf("分数 = %+v, want %+v", scores, tt.want)
+			}
+			if !reflect.DeepEqual(got, tt.adjust) {
+				t.Errorf("校正 = %v, want %v", got, tt.adjust)
+			}
+		})
+	}
+}
+
+func TestScoreNormalizerDisabled(t *testing.T) {
+	normalizer := NewScoreNormalizer(config.ScoreNormalizationConfig{})
+	if normalizer != nil {
+		t.Fatal("未启用时应返回nil")
+	}
+	scores := model.Scores{All: 120}
+	if adjustments := normalizer.Normalize(&scores, Totals{All: 50}); adjustments != nil || scores.All != 120 {
+		t.Errorf("nil校正器不应修改分数: %+v, %v", scores, adjustments)
+	}
+}
+
+func TestUpstreamTotals(t *testing.T) {
+	totals := Totals{All: 50, Dimensions: map[string]int64{DimensionContent: 20, DimensionExpression: 15}}
+	tests := []struct {
+		name string
+		cfg  config.ScoreNormalizationConfig
+		want Totals
+	}{
+		{
+			name: "未启用时取请求满分",
+			want: totals,
+		},
+		{
+			name: "未配置分制时取请求满分",
+			cfg:  config.ScoreNormalizationConfig{Enabled: true},
+			want: totals,
+		},
+		{
+			name: "配置了分制的项取上游分制",
+			cfg:  config.ScoreNormalizationConfig{Enabled: true, UpstreamScale: config.ScoreScaleConfig{All: 100, Content: 40, Structure: 20}},
+			want: Totals{All: 100, Dimensions: map[string]int64{DimensionContent: 40, DimensionExpression: 15, DimensionStructure: 20}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewScoreNormalizer(tt.cfg).UpstreamTotals(totals); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpstreamTotals = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if totals.Dimensions[DimensionContent] != 20 {
+		t.Errorf("UpstreamTotals 不应修改传入的满分: %+v", totals)
+	}
+}
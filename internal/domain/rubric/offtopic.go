@@ -0,0 +1,76 @@
+package rubric
+
+import (
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// AdjustmentOffTopic 偏题限分
+const AdjustmentOffTopic = "off_topic"
+
+// OffTopicGate 按切题度给偏题/离题作文限分
+//
+// 切题度来自总评步骤，分数来自评分步骤，两个步骤都完成后才能判定。
+type OffTopicGate struct {
+	rules []config.OffTopicRuleConfig
+}
+
+// NewOffTopicGate 创建偏题限分，未启用或未配置规则时返回nil
+func NewOffTopicGate(cfg config.OffTopicConfig) *OffTopicGate {
+	if !cfg.Enabled || len(cfg.Rules) == 0 {
+		return nil
+	}
+	// 阈值从低到高，判定时取第一条命中的即最严格的规则
+	rules := append([]config.OffTopicRuleConfig(nil), cfg.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Below < rules[j].Below })
+	return &OffTopicGate{rules: rules}
+}
+
+// Apply 切题度低于阈值时标记偏题并限分，限分记录为校正，返回是否偏题
+func (g *OffTopicGate) Apply(evaluation *model.ScoreEvaluation, relevance int, totals Totals) bool {
+	if g == nil || evaluation == nil {
+		return false
+	}
+	var rule *config.OffTopicRuleConfig
+	for i := range g.rules {
+		if relevance < g.rules[i].Below {
+			rule = &g.rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		return false
+	}
+
+	reasons := []string{fmt.Sprintf("切题度 %d 低于%s阈值 %d", relevance, rule.Name, rule.Below)}
+	capScore := func(field, label string, value *int64, total int64, ratio float64) {
+		if total <= 0 || ratio <= 0 {
+			return
+		}
+		limit := int64(math.Floor(ratio*float64(total) + ratioEpsilon))
+		limitText := fmt.Sprintf("%s不超过满分的 %s（%d 分）", label, percent(ratio), limit)
+		reasons = append(reasons, limitText)
+		reason := rule.Name + "作文" + limitText
+		if *value <= limit {
+			return
+		}
+		evaluation.Adjustments = append(evaluation.Adjustments, model.ScoreAdjustment{
+			Field:    field,
+			Kind:     AdjustmentOffTopic,
+			Original: *value,
+			Adjusted: limit,
+			Reason:   reason,
+		})
+		*value = limit
+	}
+	capScore(fieldAll, "总分", &evaluation.Scores.All, totals.All, rule.MaxRatio)
+	capScore(DimensionContent, "内容分", &evaluation.Scores.Content, totals.Dimensions[DimensionContent], rule.ContentMaxRatio)
+
+	evaluation.OffTopic = true
+	evaluation.OffTopicReason = strings.Join(reasons, "，")
+	return true
+}
@@ -0,0 +1,327 @@
+package rubric
+
+import (
+	"errors"
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidRubric 评分量表不合法（请求参数错误）
+var ErrInvalidRubric = errors.New("评分量表不合法")
+
+// Registry 可通过ID引用的评分量表
+type Registry struct {
+	rubrics map[string]*model.Rubric
+}
+
+// NewRegistry 加载配置中的评分量表，结构不合法的量表记录错误后跳过
+func NewRegistry(cfgs []config.RubricConfig) *Registry {
+	registry := &Registry{rubrics: make(map[string]*model.Rubric)}
+	for _, cfg := range cfgs {
+		rubric := rubricFromConfig(cfg)
+		if err := validateStructure(rubric); err != nil {
+			logrus.Errorf("评分量表 [%s] 配置错误，已忽略: %v", cfg.ID, err)
+			continue
+		}
+		registry.rubrics[cfg.ID] = rubric
+	}
+	return registry
+}
+
+// Resolve 解析请求中的评分量表：按rubricId取出配置的量表，校验结构并与各维度总分核对，
+// 请求未指定的维度总分和总分按量表补全
+func (r *Registry) Resolve(req *model.EvaluateRequest) error {
+	if req.Rubric != nil && req.RubricID != nil {
+		return fmt.Errorf("%w: rubric与rubricId只能指定一个", ErrInvalidRubric)
+	}
+	if req.RubricID != nil {
+		rubric, ok := r.rubrics[*req.RubricID]
+		if !ok {
+			return fmt.Errorf("%w: 评分量表 %s 不存在", ErrInvalidRubric, *req.RubricID)
+		}
+		req.Rubric = cloneRubric(rubric)
+	}
+	if req.Rubric == nil {
+		return nil
+	}
+
+	if err := validateStructure(req.Rubric); err != nil {
+		return err
+	}
+	return reconcileTotals(req.Rubric, req)
+}
+
+// validateStructure 校验评分项ID唯一、分值为正、档次下限在分值范围内
+func validateStructure(rubric *model.Rubric) error {
+	if len(rubric.Criteria) == 0 {
+		return fmt.Errorf("%w: 至少需要一个评分项", ErrInvalidRubric)
+	}
+
+	var problems []string
+	seen := make(map[string]bool)
+	for i, criterion := range rubric.Criteria {
+		label := fmt.Sprintf("评分项[%d]", i)
+		if criterion.ID == "" {
+			problems = append(problems, label+" 缺少id")
+		} else if seen[criterion.ID] {
+			problems = append(problems, label+" id重复: "+criterion.ID)
+		}
+		seen[criterion.ID] = true
+
+		if criterion.MaxPoints <= 0 {
+			problems = append(problems, fmt.Sprintf("%s 分值必须大于0", label))
+		}
+		if criterion.Dimension != "" && dimensionNames[criterion.Dimension] == "" {
+			problems = append(problems, fmt.Sprintf("%s 未知维度: %s", label, criterion.Dimension))
+		}
+		for _, level := range criterion.Levels {
+			if level.Name == "" {
+				problems = append(problems, label+" 档次缺少名称")
+			}
+			if level.MinPoints < 0 || level.MinPoints > criterion.MaxPoints {
+				problems = append(problems, fmt.Sprintf("%s 档次 %s 下限 %d 超出 0~%d", label, level.Name, level.MinPoints, criterion.MaxPoints))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidRubric, strings.Join(problems, "；"))
+	}
+	return nil
+}
+
+// reconcileTotals 各维度评分项分值之和须等于请求中该维度的总分，所有评分项之和须等于总分
+func reconcileTotals(rubric *model.Rubric, req *model.EvaluateRequest) error {
+	var all int64
+	sums := make(map[string]int64)
+	for _, criterion := range rubric.Criteria {
+		all += criterion.MaxPoints
+		if criterion.Dimension != "" {
+			sums[criterion.Dimension] += criterion.MaxPoints
+		}
+	}
+
+	var problems []string
+	check := func(name string, requested **int64, sum int64) {
+		if sum == 0 {
+			return
+		}
+		if *requested == nil {
+			*requested = &sum
+			return
+		}
+		if **requested != sum {
+			problems = append(problems, fmt.Sprintf("%s评分项分值之和为 %d，与请求的 %d 不一致", name, sum, **requested))
+		}
+	}
+	check("内容", &req.ContentScore, sums[DimensionContent])
+	check("表达", &req.ExpressionScore, sums[DimensionExpression])
+	check("结构", &req.StructureScore, sums[DimensionStructure])
+	check("发展", &req.DevelopmentScore, sums[DimensionDevelopment])
+	if sums[DimensionAppearance] > 0 && !req.HasImages() {
+		problems = append(problems, "卷面评分项需要同时提供原稿图片images")
+	}
+	check("卷面", &req.AppearanceScore, sums[DimensionAppearance])
+	check("全部", &req.TotalScore, all)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidRubric, strings.Join(problems, "；"))
+	}
+	return nil
+}
+
+// Text 把量表渲染为文本，供只接受自由文本评分标准的上游使用
+func Text(rubric *model.Rubric) string {
+	var b strings.Builder
+	if rubric.Name != "" {
+		b.WriteString(rubric.Name)
+		b.WriteString("\n")
+	}
+	for i, criterion := range rubric.Criteria {
+		fmt.Fprintf(&b, "%d. %s（%d分", i+1, criterion.Name, criterion.MaxPoints)
+		if criterion.Dimension != "" {
+			fmt.Fprintf(&b, "，%s", dimensionName(criterion.Dimension))
+		}
+		b.WriteString("）")
+		if criterion.Description != "" {
+			b.WriteString("：")
+			b.WriteString(criterion.Description)
+		}
+		b.WriteString("\n")
+		for _, level := range sortedLevels(criterion.Levels) {
+			fmt.Fprintf(&b, "   - %s（%d分以上）", level.Name, level.MinPoints)
+			if level.Descriptor != "" {
+				b.WriteString("：")
+				b.WriteString(level.Descriptor)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// CriterionResult 上游按评分项返回的得分
+type CriterionResult struct {
+	Score   int64
+	Comment string
+}
+
+// Breakdown 按量表给出各评分项得分
+//
+// 上游给出评分项得分时直接使用；否则把所属维度的分数按分值比例分摊到该维度的各评分项（最大余数法，保证总和不变），
+// 不属于任何维度的评分项分摊总分中扣除各维度后的剩余部分。
+func Breakdown(rubric *model.Rubric, scores model.Scores, upstream map[string]CriterionResult) []model.CriterionScore {
+	if rubric == nil {
+		return nil
+	}
+
+	results := make([]model.CriterionScore, len(rubric.Criteria))
+	groups := make(map[string][]int)
+	for i, criterion := range rubric.Criteria {
+		results[i] = model.CriterionScore{
+			CriterionID: criterion.ID,
+			Name:        criterion.Name,
+			Dimension:   criterion.Dimension,
+			MaxPoints:   criterion.MaxPoints,
+		}
+		if result, ok := upstream[criterion.ID]; ok {
+			results[i].Score = min(max(result.Score, 0), criterion.MaxPoints)
+			results[i].Comment = result.Comment
+			continue
+		}
+		groups[criterion.Dimension] = append(groups[criterion.Dimension], i)
+	}
+
+	dimensionScores := DimensionScores(scores)
+	remaining := scores.All
+	for dimension := range sums(rubric) {
+		remaining -= dimensionScores[dimension]
+	}
+
+	for dimension, indexes := range groups {
+		source := remaining
+		if dimension != "" {
+			source = dimensionScores[dimension]
+		}
+		// 已由上游给出得分的同组评分项先扣除
+		for i, criterion := range rubric.Criteria {
+			if criterion.Dimension == dimension && !contains(indexes, i) {
+				source -= results[i].Score
+			}
+		}
+
+		weights := make([]int64, len(indexes))
+		for j, i := range indexes {
+			weights[j] = rubric.Criteria[i].MaxPoints
+		}
+		for j, share := range apportion(source, weights) {
+			results[indexes[j]].Score = share
+			results[indexes[j]].Estimated = true
+		}
+	}
+
+	for i, criterion := range rubric.Criteria {
+		for _, level := range sortedLevels(criterion.Levels) {
+			if results[i].Score >= level.MinPoints {
+				results[i].Level = level.Name
+				results[i].LevelDescriptor = level.Descriptor
+				break
+			}
+		}
+	}
+	return results
+}
+
+// sums 各维度评分项分值之和
+func sums(rubric *model.Rubric) map[string]int64 {
+	result := make(map[string]int64)
+	for _, criterion := range rubric.Criteria {
+		if criterion.Dimension != "" {
+			result[criterion.Dimension] += criterion.MaxPoints
+		}
+	}
+	return result
+}
+
+// apportion 按权重分摊分数（最大余数法），分数先限制在 0~权重之和
+func apportion(score int64, weights []int64) []int64 {
+	var total int64
+	for _, weight := range weights {
+		total += weight
+	}
+	shares := make([]int64, len(weights))
+	if total <= 0 {
+		return shares
+	}
+	score = min(max(score, 0), total)
+
+	type remainder struct {
+		index int
+		value int64
+	}
+	remainders := make([]remainder, len(weights))
+	var assigned int64
+	for i, weight := range weights {
+		shares[i] = score * weight / total
+		assigned += shares[i]
+		remainders[i] = remainder{index: i, value: score * weight % total}
+	}
+	sort.SliceStable(remainders, func(a, b int) bool { return remainders[a].value > remainders[b].value })
+	for i := int64(0); i < score-assigned; i++ {
+		shares[remainders[i].index]++
+	}
+	return shares
+}
+
+// sortedLevels 档次按下限从高到低
+func sortedLevels(levels []model.RubricLevel) []model.RubricLevel {
+	sorted := append([]model.RubricLevel(nil), levels...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].MinPoints > sorted[j].MinPoints })
+	return sorted
+}
+
+func contains(indexes []int, target int) bool {
+	for _, i := range indexes {
+		if i == target {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneRubric(rubric *model.Rubric) *model.Rubric {
+	clone := *rubric
+	clone.Criteria = make([]model.RubricCriterion, len(rubric.Criteria))
+	for i, criterion := range rubric.Criteria {
+		criterion.Levels = append([]model.RubricLevel(nil), criterion.Levels...)
+		clone.Criteria[i] = criterion
+	}
+	return &clone
+}
+
+func rubricFromConfig(cfg config.RubricConfig) *model.Rubric {
+	rubric := &model.Rubric{ID: cfg.ID, Name: cfg.Name}
+	for _, criterion := range cfg.Criteria {
+		item := model.RubricCriterion{
+			ID:          criterion.ID,
+			Name:        criterion.Name,
+			Description: criterion.Description,
+			Dimension:   criterion.Dimension,
+			MaxPoints:   criterion.MaxPoints,
+		}
+		for _, level := range criterion.Levels {
+			item.Levels = append(item.Levels, model.RubricLevel{
+				Name:       level.Name,
+				MinPoints:  level.MinPoints,
+				Descriptor: level.Descriptor,
+			})
+		}
+		rubric.Criteria = append(rubric.Criteria, item)
+	}
+	return rubric
+}
@@ -0,0 +1,44 @@
+package rubric
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApportion(t *testing.T) {
+	tests := []struct {
+		name    string
+		score   int64
+		weights []int64
+		want    []int64
+	}{
+		{"整除", 30, []int64{10, 20}, []int64{10, 20}},
+		{"余数相同时给靠前的", 10, []int64{4, 4, 4}, []int64{4, 3, 3}},
+		{"按最大余数分配", 7, []int64{20, 15, 10, 5}, []int64{3, 2, 1, 1}},
+		{"零分", 0, []int64{5, 5}, []int64{0, 0}},
+		{"超出权重之和按权重之和", 30, []int64{10, 10}, []int64{10, 10}},
+		{"负分按0分", -5, []int64{1, 2}, []int64{0, 0}},
+		{"权重之和为0", 10, []int64{0, 0}, []int64{0, 0}},
+		{"没有权重", 10, nil, []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := apportion(tt.score, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apportion(%d, %v) = %v, want %v", tt.score, tt.weights, got, tt.want)
+			}
+			var sum int64
+			for _, share := range got {
+				sum += share
+			}
+			var total int64
+			for _, weight := range tt.weights {
+				total += weight
+			}
+			if want := min(max(tt.score, 0), total); sum != want {
+				t.Errorf("apportion(%d, %v) 之和 = %d, want %d", tt.score, tt.weights, sum, want)
+			}
+		})
+	}
+}
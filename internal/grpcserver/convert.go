@@ -0,0 +1,497 @@
+package grpcserver
+
+import (
+	essayv1 "essay-stateless/api/essay/v1"
+	"essay-stateless/internal/model"
+
+	"github.com/samber/lo"
+)
+
+// model <-> proto 转换
+
+func evaluateRequestFromProto(in *essayv1.EvaluateRequest) *model.EvaluateRequest {
+	req := &model.EvaluateRequest{
+		Title:            in.GetTitle(),
+		Content:          in.GetContent(),
+		EssayType:        in.EssayType,
+		TotalScore:       in.TotalScore,
+		Prompt:           in.Prompt,
+		Standard:         in.Standard,
+		ContentScore:     in.ContentScore,
+		ExpressionScore:  in.ExpressionScore,
+		StructureScore:   in.StructureScore,
+		DevelopmentScore: in.DevelopmentScore,
+		RubricID:         in.RubricId,
+		Images:           in.GetImages(),
+		AppearanceScore:  in.AppearanceScore,
+		ReferenceEssay:   in.ReferenceEssay,
+	}
+	if in.Grade != nil {
+		req.Grade = lo.ToPtr(int(in.GetGrade()))
+	}
+	if in.Rubric != nil {
+		req.Rubric = rubricFromProto(in.GetRubric())
+	}
+	return req
+}
+
+func rubricFromProto(in *essayv1.Rubric) *model.Rubric {
+	return &model.Rubric{
+		ID:   in.GetId(),
+		Name: in.GetName(),
+		Criteria: lo.Map(in.GetCriteria(), func(c *essayv1.RubricCriterion, _ int) model.RubricCriterion {
+			return model.RubricCriterion{
+				ID:          c.GetId(),
+				Name:        c.GetName(),
+				Description: c.GetDescription(),
+				Dimension:   c.GetDimension(),
+				MaxPoints:   c.GetMaxPoints(),
+				Levels: lo.Map(c.GetLevels(), func(l *essayv1.RubricLevel, _ int) model.RubricLevel {
+					return model.RubricLevel{Name: l.GetName(), MinPoints: l.GetMinPoints(), Descriptor: l.GetDescriptor_()}
+				}),
+			}
+		}),
+	}
+}
+
+func streamResponseToProto(in *model.StreamEvaluateResponse) *essayv1.StreamEvaluateResponse {
+	out := &essayv1.StreamEvaluateResponse{
+		Type:         in.Type,
+		Step:         in.Step,
+		Progress:     int32(in.Progress),
+		EtaSeconds:   int32(in.ETASeconds),
+		Message:      in.Message,
+		Timestamp:    in.Timestamp,
+		EvaluationId: in.EvaluationID,
+	}
+
+	switch data := in.Data.(type) {
+	case *model.StreamInitData:
+		out.Data = &essayv1.StreamEvaluateResponse_Init{Init: &essayv1.StreamInitData{
+			Title:                 data.Title,
+			Text:                  textToProto(data.Text),
+			EssayInfo:             essayInfoToProto(data.EssayInfo),
+			RequirementCompliance: requirementComplianceToProto(data.RequirementCompliance),
+			GenreEvaluation:       genreEvaluationToProto(data.GenreEvaluation),
+		}}
+	case model.AIEvaluation:
+		out.Data = &essayv1.StreamEvaluateResponse_StepData{StepData: aiEvaluationToProto(data)}
+	case *model.EvaluateResponse:
+		out.Data = &essayv1.StreamEvaluateResponse_Result{Result: evaluateResponseToProto(data)}
+	case *model.StreamErrorData:
+		out.Data = &essayv1.StreamEvaluateResponse_Error{Error: &essayv1.StreamErrorData{
+			Error:    data.Error,
+			Step:     data.Step,
+			Kind:     data.Kind,
+			Code:     data.Code,
+			Attempts: int32(data.Attempts),
+		}}
+	}
+
+	return out
+}
+
+func evaluateResponseToProto(in *model.EvaluateResponse) *essayv1.EvaluateResponse {
+	return &essayv1.EvaluateResponse{
+		Title:        in.Title,
+		Text:         textToProto(in.Text),
+		EssayInfo:    essayInfoToProto(in.EssayInfo),
+		AiEvaluation: aiEvaluationToProto(in.AIEvaluation),
+	}
+}
+
+func textToProto(text [][]string) []*essayv1.Paragraph {
+	return lo.Map(text, func(sentences []string, _ int) *essayv1.Paragraph {
+		return &essayv1.Paragraph{Sentences: sentences}
+	})
+}
+
+func essayInfoToProto(in model.EssayInfo) *essayv1.EssayInfo {
+	c := in.Counting
+	return &essayv1.EssayInfo{
+		EssayType: in.EssayType,
+		Grade:     int32(in.Grade),
+		Score:     in.AllScore,
+		Counting: &essayv1.Counting{
+			AdjAdvNum:         int32(c.AdjAdvNum),
+			CharNum:           int32(c.CharNum),
+			DieciNum:          int32(c.DieciNum),
+			Fluency:           int32(c.Fluency),
+			GrammarMistakeNum: int32(c.GrammarMistakeNum),
+			HighlightSentsNum: int32(c.HighlightSentsNum),
+			IdiomNum:          int32(c.IdiomNum),
+			NounTypeNum:       int32(c.NounTypeNum),
+			ParaNum:           int32(c.ParaNum),
+			SentNum:           int32(c.SentNum),
+			UniqueWordNum:     int32(c.UniqueWordNum),
+			VerbTypeNum:       int32(c.VerbTypeNum),
+			WordNum:           int32(c.WordNum),
+			WrittenMistakeNum: int32(c.WrittenMistakeNum),
+		},
+		Source:      in.Source,
+		Approximate: in.Approximate,
+		Unavailable: in.Unavailable,
+	}
+}
+
+func aiEvaluationToProto(in model.AIEvaluation) *essayv1.AIEvaluation {
+	return &essayv1.AIEvaluation{
+		ModelVersion: &essayv1.ModelVersion{
+			Name:    in.ModelVersion.Name,
+			Version: in.ModelVersion.Version,
+		},
+		OverallEvaluation: &essayv1.OverallEvaluation{
+			Description:         in.OverallEvaluation.Description,
+			TopicRelevanceScore: int32(in.OverallEvaluation.TopicRelevanceScore),
+		},
+		WordSentenceEvaluation: wordSentenceEvaluationToProto(in.WordSentenceEvaluation),
+		SuggestionEvaluation: &essayv1.SuggestionEvaluation{
+			SuggestionDescription: in.SuggestionEvaluation.SuggestionDescription,
+		},
+		ParagraphEvaluations: lo.Map(in.ParagraphEvaluations, func(p model.ParagraphEvaluation, _ int) *essayv1.ParagraphEvaluation {
+			return &essayv1.ParagraphEvaluation{ParagraphIndex: int32(p.ParagraphIndex), Comment: p.Comment}
+		}),
+		ScoreEvaluation:       scoreEvaluationToProto(in.ScoreEvaluation),
+		RequirementCompliance: requirementComplianceToProto(in.RequirementCompliance),
+		GenreEvaluation:       genreEvaluationToProto(in.GenreEvaluation),
+		ReferenceComparison:   referenceComparisonToProto(in.ReferenceComparison),
+		PolishingEvaluation: lo.Map(in.PolishingEvaluation, func(p model.PolishingEvaluation, _ int) *essayv1.PolishingEvaluation {
+			return &essayv1.PolishingEvaluation{
+				ParagraphIndex: int32(p.ParagraphIndex),
+				Edits: lo.Map(p.Edits, func(e model.PolishingEdit, _ int) *essayv1.PolishingEdit {
+					return &essayv1.PolishingEdit{
+						Op:            e.Op,
+						Reason:        e.Reason,
+						Original:      e.Original,
+						Revised:       e.Revised,
+						SentenceIndex: int32(e.SentenceIndex),
+						Span:          intsToProto(e.Span),
+					}
+				}),
+			}
+		}),
+	}
+}
+
+func wordSentenceEvaluationToProto(in model.WordSentenceEvaluation) *essayv1.WordSentenceEvaluation {
+	return &essayv1.WordSentenceEvaluation{
+		WordSentenceScore: int32(in.WordSentenceScore),
+		SentenceEvaluations: lo.Map(in.SentenceEvaluations, func(paragraph []model.SentenceEvaluation, _ int) *essayv1.ParagraphSentenceEvaluations {
+			return &essayv1.ParagraphSentenceEvaluations{
+				Sentences: lo.Map(paragraph, func(s model.SentenceEvaluation, _ int) *essayv1.SentenceEvaluation {
+					return &essayv1.SentenceEvaluation{
+						IsGoodSentence: s.IsGoodSentence,
+						Label:          s.Label,
+						Type:           s.Type,
+						WordEvaluations: lo.Map(s.WordEvaluations, func(w model.WordEvaluation, _ int) *essayv1.WordEvaluation {
+							return &essayv1.WordEvaluation{
+								Span:    intsToProto(w.Span),
+								Type:    w.Type,
+								Ori:     w.Ori,
+								Revised: w.Revised,
+							}
+						}),
+					}
+				}),
+			}
+		}),
+	}
+}
+
+func wordSentenceEvaluationFromProto(in *essayv1.WordSentenceEvaluation) model.WordSentenceEvaluation {
+	return model.WordSentenceEvaluation{
+		WordSentenceScore: int(in.GetWordSentenceScore()),
+		SentenceEvaluations: lo.Map(in.GetSentenceEvaluations(), func(paragraph *essayv1.ParagraphSentenceEvaluations, _ int) []model.SentenceEvaluation {
+			return lo.Map(paragraph.GetSentences(), func(s *essayv1.SentenceEvaluation, _ int) model.SentenceEvaluation {
+				return model.SentenceEvaluation{
+					IsGoodSentence: s.GetIsGoodSentence(),
+					Label:          s.GetLabel(),
+					Type:           s.GetType(),
+					WordEvaluations: lo.Map(s.GetWordEvaluations(), func(w *essayv1.WordEvaluation, _ int) model.WordEvaluation {
+						return model.WordEvaluation{
+							Span:    intsFromProto(w.GetSpan()),
+							Type:    w.GetType(),
+							Ori:     w.GetOri(),
+							Revised: w.GetRevised(),
+						}
+					}),
+				}
+			})
+		}),
+	}
+}
+
+func scoreEvaluationToProto(in model.ScoreEvaluation) *essayv1.ScoreEvaluation {
+	return &essayv1.ScoreEvaluation{
+		Comment: in.Comment,
+		Comments: &essayv1.Comments{
+			Appearance:  in.Comments.Appearance,
+			Content:     in.Comments.Content,
+			Expression:  in.Comments.Expression,
+			Structure:   in.Comments.Structure,
+			Development: in.Comments.Development,
+		},
+		Scores: &essayv1.Scores{
+			All:                  in.Scores.All,
+			Appearance:           in.Scores.Appearance,
+			Content:              in.Scores.Content,
+			Expression:           in.Scores.Expression,
+			Structure:            in.Scores.Structure,
+			Development:          in.Scores.Development,
+			AllWithTotal:         in.Scores.AllWithTotal,
+			ContentWithTotal:     in.Scores.ContentWithTotal,
+			ExpressionWithTotal:  in.Scores.ExpressionWithTotal,
+			StructureWithTotal:   in.Scores.StructureWithTotal,
+			DevelopmentWithTotal: in.Scores.DevelopmentWithTotal,
+			AppearanceWithTotal:  in.Scores.AppearanceWithTotal,
+		},
+		ExamBand: examBandToProto(in.ExamBand),
+		Criteria: lo.Map(in.Criteria, func(c model.CriterionScore, _ int) *essayv1.CriterionScore {
+			return &essayv1.CriterionScore{
+				CriterionId:     c.CriterionID,
+				Name:            c.Name,
+				Dimension:       c.Dimension,
+				Score:           c.Score,
+				MaxPoints:       c.MaxPoints,
+				Level:           c.Level,
+				LevelDescriptor: c.LevelDescriptor,
+				Comment:         c.Comment,
+				Estimated:       c.Estimated,
+			}
+		}),
+		Adjustments: lo.Map(in.Adjustments, func(a model.ScoreAdjustment, _ int) *essayv1.ScoreAdjustment {
+			return &essayv1.ScoreAdjustment{Field: a.Field, Kind: a.Kind, Original: a.Original, Adjusted: a.Adjusted, Reason: a.Reason}
+		}),
+		OffTopic:       in.OffTopic,
+		OffTopicReason: in.OffTopicReason,
+		Confidence:     scoreConfidenceToProto(in.Confidence),
+		Evidence:       evidenceToProto(in.Evidence),
+	}
+}
+
+func evidenceToProto(in map[string][]model.EvidenceRef) map[string]*essayv1.EvidenceList {
+	if len(in) == 0 {
+		return nil
+	}
+	return lo.MapValues(in, func(refs []model.EvidenceRef, _ string) *essayv1.EvidenceList {
+		return &essayv1.EvidenceList{Refs: evidenceRefsToProto(refs)}
+	})
+}
+
+func evidenceRefsToProto(refs []model.EvidenceRef) []*essayv1.EvidenceRef {
+	return lo.Map(refs, func(r model.EvidenceRef, _ int) *essayv1.EvidenceRef {
+		return &essayv1.EvidenceRef{
+			Source:         r.Source,
+			Polarity:       r.Polarity,
+			ParagraphIndex: int32(r.ParagraphIndex),
+			SentenceIndex:  int32(r.SentenceIndex),
+			Span:           lo.Map(r.Span, func(v int, _ int) int32 { return int32(v) }),
+			Text:           r.Text,
+			Note:           r.Note,
+		}
+	})
+}
+
+func referenceComparisonToProto(in *model.ReferenceComparison) *essayv1.ReferenceComparison {
+	if in == nil {
+		return nil
+	}
+	toInt32s := func(values []int) []int32 {
+		return lo.Map(values, func(v int, _ int) int32 { return int32(v) })
+	}
+	points := func(points []model.ReferencePoint) []*essayv1.ReferencePoint {
+		return lo.Map(points, func(p model.ReferencePoint, _ int) *essayv1.ReferencePoint {
+			return &essayv1.ReferencePoint{
+				ReferenceParagraphIndex: int32(p.ReferenceParagraphIndex),
+				Summary:                 p.Summary,
+				KeyPhrases:              p.KeyPhrases,
+				Coverage:                p.Coverage,
+				ParagraphIndex:          int32(p.ParagraphIndex),
+			}
+		})
+	}
+	return &essayv1.ReferenceComparison{
+		Similarity: in.Similarity,
+		Structure: &essayv1.StructureComparison{
+			ParagraphCount:            int32(in.Structure.ParagraphCount),
+			ReferenceParagraphCount:   int32(in.Structure.ReferenceParagraphCount),
+			CharCount:                 int32(in.Structure.CharCount),
+			ReferenceCharCount:        int32(in.Structure.ReferenceCharCount),
+			ParagraphLengths:          toInt32s(in.Structure.ParagraphLengths),
+			ReferenceParagraphLengths: toInt32s(in.Structure.ReferenceLengths),
+			Differences:               in.Structure.Differences,
+		},
+		SharedPhrases: in.SharedPhrases,
+		Covered:       points(in.Covered),
+		Missed:        points(in.Missed),
+	}
+}
+
+func genreEvaluationToProto(in *model.GenreEvaluation) *essayv1.GenreEvaluation {
+	if in == nil {
+		return nil
+	}
+	return &essayv1.GenreEvaluation{
+		Profile:   in.Profile,
+		EssayType: in.EssayType,
+		Checks: lo.Map(in.Checks, func(c model.GenreCheck, _ int) *essayv1.GenreCheck {
+			return &essayv1.GenreCheck{
+				Kind:     c.Kind,
+				Name:     c.Name,
+				Passed:   c.Passed,
+				Detail:   c.Detail,
+				Evidence: evidenceRefsToProto(c.Evidence),
+			}
+		}),
+	}
+}
+
+func requirementComplianceToProto(in *model.RequirementCompliance) *essayv1.RequirementCompliance {
+	if in == nil {
+		return nil
+	}
+	return &essayv1.RequirementCompliance{
+		AllMet: in.AllMet,
+		Requirements: lo.Map(in.Requirements, func(r model.RequirementCheck, _ int) *essayv1.RequirementCheck {
+			return &essayv1.RequirementCheck{Kind: r.Kind, Requirement: r.Requirement, Met: r.Met, Evidence: r.Evidence}
+		}),
+	}
+}
+
+func scoreConfidenceToProto(in *model.ScoreConfidence) *essayv1.ScoreConfidence {
+	if in == nil {
+		return nil
+	}
+	return &essayv1.ScoreConfidence{
+		Runs:          int32(in.Runs),
+		Failed:        int32(in.Failed),
+		Aggregate:     in.Aggregate,
+		LowConfidence: in.LowConfidence,
+		Reason:        in.Reason,
+		Spreads: lo.Map(in.Spreads, func(s model.ScoreSpread, _ int) *essayv1.ScoreSpread {
+			return &essayv1.ScoreSpread{Field: s.Field, Values: s.Values, Aggregated: s.Aggregated, Spread: s.Spread, StdDev: s.StdDev}
+		}),
+	}
+}
+
+func examBandToProto(in *model.ExamBandEvaluation) *essayv1.ExamBandEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := &essayv1.ExamBandEvaluation{
+		Standard:     in.Standard,
+		StandardName: in.StandardName,
+		Dimensions: lo.Map(in.Dimensions, func(d model.DimensionBandResult, _ int) *essayv1.DimensionBandResult {
+			return &essayv1.DimensionBandResult{
+				Dimension: d.Dimension,
+				Group:     d.Group,
+				Band:      bandResultToProto(&d.BandResult),
+			}
+		}),
+	}
+	if in.Band != nil {
+		out.Band = bandResultToProto(in.Band)
+	}
+	return out
+}
+
+func bandResultToProto(in *model.BandResult) *essayv1.BandResult {
+	return &essayv1.BandResult{
+		Name:        in.Name,
+		Descriptor_: in.Descriptor,
+		Score:       in.Score,
+		Total:       in.Total,
+		Ratio:       in.Ratio,
+		Reasons:     in.Reasons,
+	}
+}
+
+func scoreEvaluationFromProto(in *essayv1.ScoreEvaluation) model.ScoreEvaluation {
+	comments := in.GetComments()
+	scores := in.GetScores()
+	return model.ScoreEvaluation{
+		Comment: in.GetComment(),
+		Comments: model.Comments{
+			Appearance:  comments.GetAppearance(),
+			Content:     comments.GetContent(),
+			Expression:  comments.GetExpression(),
+			Structure:   comments.GetStructure(),
+			Development: comments.GetDevelopment(),
+		},
+		Scores: model.Scores{
+			All:                  scores.GetAll(),
+			Appearance:           scores.GetAppearance(),
+			Content:              scores.GetContent(),
+			Expression:           scores.GetExpression(),
+			Structure:            scores.GetStructure(),
+			Development:          scores.GetDevelopment(),
+			AllWithTotal:         scores.GetAllWithTotal(),
+			ContentWithTotal:     scores.GetContentWithTotal(),
+			ExpressionWithTotal:  scores.GetExpressionWithTotal(),
+			StructureWithTotal:   scores.GetStructureWithTotal(),
+			DevelopmentWithTotal: scores.GetDevelopmentWithTotal(),
+			AppearanceWithTotal:  scores.GetAppearanceWithTotal(),
+		},
+	}
+}
+
+func classStatisticsRequestFromProto(in *essayv1.ClassStatisticsRequest) model.ClassStatisticsRequest {
+	return model.ClassStatisticsRequest{
+		TotalStudents: int(in.GetTotalStudents()),
+		SubmittedStudents: lo.Map(in.GetSubmittedStudents(), func(s *essayv1.StatisticsRequest, _ int) model.StatisticsRequest {
+			return model.StatisticsRequest{
+				WordSentenceEvaluation: wordSentenceEvaluationFromProto(s.GetWordSentenceEvaluation()),
+				ScoreEvaluation:        scoreEvaluationFromProto(s.GetScoreEvaluation()),
+			}
+		}),
+	}
+}
+
+func classStatisticsResponseToProto(in *model.ClassStatisticsResponse) *essayv1.ClassStatisticsResponse {
+	return &essayv1.ClassStatisticsResponse{
+		SubmissionPercentage: in.SubmissionPercentage,
+		GeneratedTime:        in.GeneratedTime,
+		OverallPerformance: &essayv1.OverallPerformance{
+			AverageScore: in.OverallPerformance.AverageScore,
+			Summary:      in.OverallPerformance.Summary,
+			GradeDistribution: lo.Map(in.OverallPerformance.GradeDistribution, func(g model.GradeDistributionItem, _ int) *essayv1.GradeDistributionItem {
+				return &essayv1.GradeDistributionItem{Grade: g.Grade, StudentCount: int32(g.StudentCount), Percentage: g.Percentage}
+			}),
+			SkillMasteryAnalysis: lo.Map(in.OverallPerformance.SkillMasteryAnalysis, func(s model.SkillMasteryItem, _ int) *essayv1.SkillMasteryItem {
+				return &essayv1.SkillMasteryItem{
+					SkillName: s.SkillName,
+					GradeDistribution: lo.Map(s.GradeDistribution, func(g model.SkillGradeDistribution, _ int) *essayv1.GradeDistributionItem {
+						return &essayv1.GradeDistributionItem{Grade: g.Grade, StudentCount: int32(g.StudentCount), Percentage: g.Percentage}
+					}),
+				}
+			}),
+		},
+		ErrorAnalysis: &essayv1.ErrorAnalysis{
+			ErrorDistribution: lo.Map(in.ErrorAnalysis.ErrorDistribution, func(e model.ErrorDistributionItem, _ int) *essayv1.ErrorDistributionItem {
+				return &essayv1.ErrorDistributionItem{ErrorCount: e.ErrorCount, StudentCount: int32(e.StudentCount), Percentage: e.Percentage}
+			}),
+			ErrorTypeRatio: lo.Map(in.ErrorAnalysis.ErrorTypeRatio, func(e model.ErrorTypeItem, _ int) *essayv1.ErrorTypeItem {
+				return &essayv1.ErrorTypeItem{ErrorType: e.ErrorType, Count: int32(e.Count), Percentage: e.Percentage, StudentCount: int32(e.StudentCount)}
+			}),
+			HighFrequencyList: lo.Map(in.ErrorAnalysis.HighFrequencyList, func(e model.HighFrequencyError, _ int) *essayv1.HighFrequencyError {
+				return &essayv1.HighFrequencyError{ErrorText: e.ErrorText, ErrorType: e.ErrorType, Count: int32(e.Count), Examples: e.Examples}
+			}),
+		},
+		HighlightAnalysis: &essayv1.HighlightAnalysis{
+			HighlightDistribution: lo.Map(in.HighlightAnalysis.HighlightDistribution, func(h model.HighlightDistributionItem, _ int) *essayv1.HighlightDistributionItem {
+				return &essayv1.HighlightDistributionItem{HighlightCount: h.HighlightCount, StudentCount: int32(h.StudentCount), Percentage: h.Percentage}
+			}),
+			HighlightTypeRatio: lo.Map(in.HighlightAnalysis.HighlightTypeRatio, func(h model.HighlightTypeItem, _ int) *essayv1.HighlightTypeItem {
+				return &essayv1.HighlightTypeItem{HighlightType: h.HighlightType, Count: int32(h.Count), Percentage: h.Percentage, StudentCount: int32(h.StudentCount)}
+			}),
+		},
+	}
+}
+
+func intsToProto(in []int) []int32 {
+	return lo.Map(in, func(v int, _ int) int32 { return int32(v) })
+}
+
+func intsFromProto(in []int32) []int {
+	return lo.Map(in, func(v int32, _ int) int { return int(v) })
+}
@@ -0,0 +1,212 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	essayv1 "essay-stateless/api/essay/v1"
+	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Server gRPC服务实现，与HTTP Handler共用应用服务层
+type Server struct {
+	essayv1.UnimplementedEssayServiceServer
+
+	evaluateService   *appService.EvaluateServiceV2
+	ocrService        *appService.OcrServiceV2
+	statisticsService *appService.StatisticsServiceV2
+	rawLogsRepo       repository.RawLogsRepository
+}
+
+// NewServer 创建gRPC服务实现
+func NewServer(
+	evaluateService *appService.EvaluateServiceV2,
+	ocrService *appService.OcrServiceV2,
+	statisticsService *appService.StatisticsServiceV2,
+	rawLogsRepo repository.RawLogsRepository,
+) *Server {
+	return &Server{
+		evaluateService:   evaluateService,
+		ocrService:        ocrService,
+		statisticsService: statisticsService,
+		rawLogsRepo:       rawLogsRepo,
+	}
+}
+
+// NewGRPCServer 创建grpc.Server并注册服务
+//
+// otelgrpc使用trace.Init中设置的全局Propagator，调用方的trace上下文会透传到服务层
+func NewGRPCServer(srv *Server) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(traceIDUnaryInterceptor),
+		grpc.ChainStreamInterceptor(traceIDStreamInterceptor),
+	)
+	essayv1.RegisterEssayServiceServer(s, srv)
+	return s
+}
+
+// EvaluateStream 流式批改
+func (s *Server) EvaluateStream(pbReq *essayv1.EvaluateRequest, stream grpc.ServerStreamingServer[essayv1.StreamEvaluateResponse]) error {
+	ctx := stream.Context()
+	req := evaluateRequestFromProto(pbReq)
+	if err := s.evaluateService.PrepareRequest(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID := "anonymous"
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-user-id"); len(values) > 0 && values[0] != "" {
+			userID = values[0]
+		}
+	}
+
+	ch := make(chan *model.StreamEvaluateResponse, 50)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logrus.WithField("panic", r).Error("Panic in grpc stream evaluation")
+				select {
+				case ch <- &model.StreamEvaluateResponse{
+					Type:      "error",
+					Step:      "panic",
+					Message:   "服务内部错误",
+					Data:      &model.StreamErrorData{Error: "internal error", Step: "panic"},
+					Timestamp: time.Now().Unix(),
+				}:
+				default:
+				}
+			}
+		}()
+
+		if err := s.evaluateService.EvaluateStream(ctx, req, userID, ch); err != nil {
+			logrus.WithError(err).Error("Failed to stream evaluate essay")
+		}
+	}()
+	// 提前返回时继续消费剩余消息，避免协调器阻塞
+	defer func() {
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Info("Client disconnected from grpc stream")
+			return status.FromContextError(ctx.Err()).Err()
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(streamResponseToProto(msg)); err != nil {
+				logrus.WithError(err).Error("Failed to send grpc stream response")
+				return err
+			}
+
+			if msg.Type == "complete" {
+				if data, err := msg.JSONString(); err == nil {
+					go s.saveRawLog("grpc:/essay.v1.EssayService/EvaluateStream", req.JSONString(), data)
+				}
+				return nil
+			}
+
+			if msg.Type == "error" {
+				return nil
+			}
+		}
+	}
+}
+
+// TitleOcr 带标题OCR识别
+func (s *Server) TitleOcr(ctx context.Context, pbReq *essayv1.TitleOcrRequest) (*essayv1.TitleOcrResponse, error) {
+	req := &model.TitleOcrRequest{
+		Images:   pbReq.GetImages(),
+		LeftType: pbReq.LeftType,
+	}
+
+	response, err := s.ocrService.TitleOcr(ctx, pbReq.GetProvider(), pbReq.GetImgType(), req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to perform title OCR")
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	if data, err := response.JSONString(); err == nil {
+		// 与HTTP接口一样把provider和imgType带在URL中，回放时才能按原参数重放
+		url := "grpc:/essay.v1.EssayService/TitleOcr/" + pbReq.GetProvider() + "/" + pbReq.GetImgType()
+		go s.saveRawLog(url, req.JSONString(), data)
+	}
+
+	return &essayv1.TitleOcrResponse{
+		Title:   response.Title,
+		Content: response.Content,
+	}, nil
+}
+
+// AnalyzeClassStatistics 班级学情统计
+func (s *Server) AnalyzeClassStatistics(ctx context.Context, pbReq *essayv1.ClassStatisticsRequest) (*essayv1.ClassStatisticsResponse, error) {
+	req := classStatisticsRequestFromProto(pbReq)
+
+	if len(req.SubmittedStudents) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "学生数据不能为空")
+	}
+
+	if req.TotalStudents > 200 {
+		return nil, status.Error(codes.InvalidArgument, "学生数量不能超过200人")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	response, err := s.statisticsService.AnalyzeClassStatistics(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "统计分析失败: "+err.Error())
+	}
+
+	go s.saveRawLog("grpc:/essay.v1.EssayService/AnalyzeClassStatistics", req.JSONString(), response.JSONString())
+
+	return classStatisticsResponseToProto(response), nil
+}
+
+func (s *Server) saveRawLog(url, request, response string) {
+	log := &model.RawLogs{
+		URL:        url,
+		Request:    request,
+		Response:   response,
+		CreateTime: time.Now(),
+	}
+
+	if err := s.rawLogsRepo.Save(context.Background(), log); err != nil {
+		logrus.WithError(err).Error("Failed to save raw log")
+	}
+}
+
+// traceIDUnaryInterceptor 在响应header中返回trace id，对应HTTP的X-Trace-Id
+func traceIDUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	setTraceIDHeader(ctx)
+	return handler(ctx, req)
+}
+
+func traceIDStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	setTraceIDHeader(ss.Context())
+	return handler(srv, ss)
+}
+
+func setTraceIDHeader(ctx context.Context) {
+	traceID := trace.SpanFromContext(ctx).SpanContext().TraceID().String()
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-trace-id", traceID)); err != nil {
+		logrus.WithError(err).Warn("Failed to set grpc trace id header")
+	}
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type AdminHandler struct {
+	auditService *appService.AuditServiceV2
+}
+
+func NewAdminHandler(auditService *appService.AuditServiceV2) *AdminHandler {
+	return &AdminHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAudit 按trace ID获取批改审计包
+func (h *AdminHandler) GetAudit(c *gin.Context) {
+	bundle, err := h.auditService.GetBundle(c.Request.Context(), c.Param("traceId"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.NewErrorResponse(404, "审计记录不存在"))
+			return
+		}
+		logrus.WithError(err).Error("Failed to get audit bundle")
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(500, "Internal server error"))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewSuccessResponse(bundle))
+}
@@ -2,10 +2,13 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/config"
 	"essay-stateless/internal/model"
 	"essay-stateless/internal/repository"
 
@@ -14,14 +17,18 @@ import (
 )
 
 type EvaluateHandler struct {
-	serviceV2   *appService.EvaluateServiceV2
-	rawLogsRepo repository.RawLogsRepository
+	serviceV2    *appService.EvaluateServiceV2
+	ocrService   *appService.OcrServiceV2
+	rawLogsRepo  repository.RawLogsRepository
+	streamConfig *config.StreamConfig
 }
 
-func NewEvaluateHandler(serviceV2 *appService.EvaluateServiceV2, rawLogsRepo repository.RawLogsRepository) *EvaluateHandler {
+func NewEvaluateHandler(serviceV2 *appService.EvaluateServiceV2, ocrService *appService.OcrServiceV2, rawLogsRepo repository.RawLogsRepository, streamConfig *config.StreamConfig) *EvaluateHandler {
 	return &EvaluateHandler{
-		serviceV2:   serviceV2,
-		rawLogsRepo: rawLogsRepo,
+		serviceV2:    serviceV2,
+		ocrService:   ocrService,
+		rawLogsRepo:  rawLogsRepo,
+		streamConfig: streamConfig,
 	}
 }
 
@@ -32,7 +39,43 @@ func (h *EvaluateHandler) EvaluateStream(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, err.Error()))
 		return
 	}
+	if err := h.serviceV2.PrepareRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, err.Error()))
+		return
+	}
 
+	h.streamSSE(c, &req, "/evaluate/stream", req.JSONString())
+}
+
+// OcrEvaluateStream 手写原稿OCR识别后流式批改，原稿图片同时发给评分接口用于卷面评分
+func (h *EvaluateHandler) OcrEvaluateStream(c *gin.Context) {
+	var ocrReq model.OcrEvaluateRequest
+	if err := c.ShouldBindJSON(&ocrReq); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, err.Error()))
+		return
+	}
+	if len(ocrReq.Images) == 0 {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, "images不能为空"))
+		return
+	}
+
+	req, err := h.ocrService.EvaluateRequest(c.Request.Context(), &ocrReq)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to perform OCR before evaluation")
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(500, "OCR识别失败"))
+		return
+	}
+	if err := h.serviceV2.PrepareRequest(req); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, err.Error()))
+		return
+	}
+
+	// 日志记录原始OCR请求，回放时重新识别后再批改
+	h.streamSSE(c, req, "/evaluate/ocr/stream", ocrReq.JSONString())
+}
+
+// streamSSE 以SSE推送批改进度，完成后以logURL和logRequest记录原始日志
+func (h *EvaluateHandler) streamSSE(c *gin.Context, req *model.EvaluateRequest, logURL, logRequest string) {
 	userID := c.GetHeader("X-User-ID")
 	if userID == "" {
 		userID = "anonymous"
@@ -43,41 +86,34 @@ func (h *EvaluateHandler) EvaluateStream(c *gin.Context) {
 	c.Writer.Header().Set("Cache-Control", "no-cache")
 	c.Writer.Header().Set("Connection", "keep-alive")
 	c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	// 关闭nginx等反向代理的响应缓冲，保证事件实时到达
+	c.Writer.Header().Set("X-Accel-Buffering", "no")
 
 	c.Writer.WriteHeader(http.StatusOK)
 
+	// 建议浏览器断线后的重连间隔
+	if h.streamConfig.RetryInterval > 0 {
+		if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", h.streamConfig.RetryInterval.Milliseconds()); err != nil {
+			logrus.WithError(err).Error("Failed to write SSE retry")
+			return
+		}
+	}
+
 	// 立即刷新响应头
 	if flusher, ok := c.Writer.(http.Flusher); ok {
 		flusher.Flush()
 	}
 
-	// 创建响应通道
-	ch := make(chan *model.StreamEvaluateResponse, 50)
-
-	// 启动流式评估
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				logrus.WithField("panic", r).Error("Panic in stream evaluation")
-				// 发送panic错误消息（如果channel还没关闭）
-				select {
-				case ch <- &model.StreamEvaluateResponse{
-					Type:      "error",
-					Step:      "panic",
-					Message:   "服务内部错误",
-					Data:      &model.StreamErrorData{Error: "internal error", Step: "panic"},
-					Timestamp: time.Now().Unix(),
-				}:
-				default:
-					// channel已关闭或已满，忽略
-				}
-			}
-		}()
+	// 心跳定时器：上游较慢时定期写入注释行，防止代理/负载均衡空闲超时断开连接
+	var heartbeat <-chan time.Time
+	if h.streamConfig.HeartbeatInterval > 0 {
+		ticker := time.NewTicker(h.streamConfig.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
 
-		if err := h.serviceV2.EvaluateStream(c.Request.Context(), &req, ch); err != nil {
-			logrus.WithError(err).Error("Failed to stream evaluate essay")
-		}
-	}()
+	ch := h.startEvaluation(c.Request.Context(), req, userID)
+	defer drainStream(ch)
 
 	// 发送SSE数据
 	for {
@@ -85,6 +121,15 @@ func (h *EvaluateHandler) EvaluateStream(c *gin.Context) {
 		case <-c.Request.Context().Done():
 			logrus.WithField("user_id", userID).Info("Client disconnected from stream")
 			return
+		case <-heartbeat:
+			// SSE注释行，客户端EventSource会自动忽略
+			if _, err := c.Writer.Write([]byte(": heartbeat\n\n")); err != nil {
+				logrus.WithError(err).Error("Failed to write SSE heartbeat")
+				return
+			}
+			if flusher, ok := c.Writer.(http.Flusher); ok {
+				flusher.Flush()
+			}
 		case msg, ok := <-ch:
 			if !ok {
 				// 通道关闭，结束
@@ -114,7 +159,7 @@ func (h *EvaluateHandler) EvaluateStream(c *gin.Context) {
 
 			// 记录日志（仅完成时）
 			if msg.Type == "complete" {
-				go h.saveRawLog("/evaluate/stream", req.JSONString(), data)
+				go h.saveRawLog(logURL, logRequest, data)
 				return // 完成后结束
 			}
 
@@ -125,6 +170,68 @@ func (h *EvaluateHandler) EvaluateStream(c *gin.Context) {
 	}
 }
 
+// GetEvaluation 查询已完成的批改记录，仅限创建该记录的用户（X-User-ID）
+func (h *EvaluateHandler) GetEvaluation(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		userID = "anonymous"
+	}
+
+	evaluation, err := h.serviceV2.GetEvaluation(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.NewErrorResponse(404, "批改记录不存在"))
+			return
+		}
+		logrus.WithError(err).Error("Failed to get evaluation")
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(500, "Internal server error"))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewSuccessResponse(evaluation))
+}
+
+// startEvaluation 启动流式评估，返回消息通道（SSE与WebSocket共用）
+func (h *EvaluateHandler) startEvaluation(ctx context.Context, req *model.EvaluateRequest, userID string) <-chan *model.StreamEvaluateResponse {
+	// 创建响应通道
+	ch := make(chan *model.StreamEvaluateResponse, 50)
+
+	// 启动流式评估
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logrus.WithField("panic", r).Error("Panic in stream evaluation")
+				// 发送panic错误消息（如果channel还没关闭）
+				select {
+				case ch <- &model.StreamEvaluateResponse{
+					Type:      "error",
+					Step:      "panic",
+					Message:   "服务内部错误",
+					Data:      &model.StreamErrorData{Error: "internal error", Step: "panic"},
+					Timestamp: time.Now().Unix(),
+				}:
+				default:
+					// channel已关闭或已满，忽略
+				}
+			}
+		}()
+
+		if err := h.serviceV2.EvaluateStream(ctx, req, userID, ch); err != nil {
+			logrus.WithError(err).Error("Failed to stream evaluate essay")
+		}
+	}()
+
+	return ch
+}
+
+// drainStream 连接提前结束时继续消费剩余消息，避免协调器阻塞在发送上
+func drainStream(ch <-chan *model.StreamEvaluateResponse) {
+	go func() {
+		for range ch {
+		}
+	}()
+}
+
 func (h *EvaluateHandler) saveRawLog(url, request, response string) {
 	log := &model.RawLogs{
 		URL:        url,
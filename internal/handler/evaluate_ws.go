@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"essay-stateless/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// WebSocket客户端消息类型
+const (
+	wsMessageEvaluate = "evaluate" // 发起批改，data为EvaluateRequest
+	wsMessageCancel   = "cancel"   // 取消批改
+	wsMessagePing     = "ping"     // 应用层心跳（部分小程序环境无法感知控制帧）
+)
+
+const wsWriteWait = 10 * time.Second
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// wsClientMessage 客户端发送的WebSocket消息
+type wsClientMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// EvaluateWebSocket WebSocket流式批改接口
+//
+// 连接建立后客户端先发送 {"type":"evaluate","data":{...}}，服务端随后推送与SSE一致的
+// StreamEvaluateResponse 消息；客户端可随时发送 {"type":"cancel"} 取消批改。
+func (h *EvaluateHandler) EvaluateWebSocket(c *gin.Context) {
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to upgrade websocket")
+		return
+	}
+	defer conn.Close()
+
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		userID = "anonymous"
+	}
+
+	pongWait := h.streamConfig.PongWait
+	conn.SetReadLimit(1 << 20)
+	h.extendReadDeadline(conn, pongWait)
+	conn.SetPongHandler(func(string) error {
+		h.extendReadDeadline(conn, pongWait)
+		return nil
+	})
+
+	// 第一条消息必须是批改请求
+	var first wsClientMessage
+	if err := conn.ReadJSON(&first); err != nil {
+		logrus.WithError(err).Error("Failed to read websocket evaluate message")
+		return
+	}
+	if first.Type != wsMessageEvaluate {
+		h.writeWSError(conn, "第一条消息必须为evaluate")
+		return
+	}
+
+	var req model.EvaluateRequest
+	if err := json.Unmarshal(first.Data, &req); err != nil {
+		h.writeWSError(conn, "请求参数格式错误: "+err.Error())
+		return
+	}
+	if err := h.serviceV2.PrepareRequest(&req); err != nil {
+		h.writeWSError(conn, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	ch := h.startEvaluation(ctx, &req, userID)
+	defer drainStream(ch)
+
+	// 读协程：接收取消/心跳消息，连接断开时通知写循环
+	clientMsgs := make(chan wsClientMessage)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var msg wsClientMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			h.extendReadDeadline(conn, pongWait)
+			select {
+			case clientMsgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var ping <-chan time.Time
+	if h.streamConfig.PingInterval > 0 {
+		ticker := time.NewTicker(h.streamConfig.PingInterval)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+
+	// 写循环：WebSocket连接只允许单个写者
+	for {
+		select {
+		case <-closed:
+			logrus.WithField("user_id", userID).Info("Client disconnected from websocket")
+			return
+		case <-ping:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				logrus.WithError(err).Error("Failed to write websocket ping")
+				return
+			}
+		case msg := <-clientMsgs:
+			switch msg.Type {
+			case wsMessageCancel:
+				cancel()
+				logrus.WithField("user_id", userID).Info("Client cancelled websocket evaluation")
+				h.writeWS(conn, &model.StreamEvaluateResponse{
+					Type:      "cancelled",
+					Step:      "cancel",
+					Message:   "作文批改已取消",
+					Timestamp: time.Now().Unix(),
+				})
+				return
+			case wsMessagePing:
+				if err := h.writeWS(conn, &model.StreamEvaluateResponse{Type: "pong", Timestamp: time.Now().Unix()}); err != nil {
+					return
+				}
+			default:
+				logrus.Warnf("未知的WebSocket消息类型: %s", msg.Type)
+			}
+		case msg, ok := <-ch:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteWait))
+				return
+			}
+
+			if err := h.writeWS(conn, msg); err != nil {
+				return
+			}
+
+			if msg.Type == "complete" {
+				data, _ := msg.JSONString()
+				go h.saveRawLog("/evaluate/ws", req.JSONString(), data)
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteWait))
+				return
+			}
+
+			if msg.Type == "error" {
+				return
+			}
+		}
+	}
+}
+
+// writeWS 写入一条流式响应
+func (h *EvaluateHandler) writeWS(conn *websocket.Conn, msg *model.StreamEvaluateResponse) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	if err := conn.WriteJSON(msg); err != nil {
+		logrus.WithError(err).Error("Failed to write websocket message")
+		return err
+	}
+	return nil
+}
+
+// writeWSError 写入错误消息
+func (h *EvaluateHandler) writeWSError(conn *websocket.Conn, message string) {
+	h.writeWS(conn, &model.StreamEvaluateResponse{
+		Type:      "error",
+		Step:      "request",
+		Message:   message,
+		Data:      &model.StreamErrorData{Error: message, Step: "request"},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// extendReadDeadline 收到任意消息或pong后延长读超时
+func (h *EvaluateHandler) extendReadDeadline(conn *websocket.Conn, pongWait time.Duration) {
+	if pongWait > 0 {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+	}
+}
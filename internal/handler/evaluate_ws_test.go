@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type countingEvaluationRepo struct {
+	saves atomic.Int32
+}
+
+func (r *countingEvaluationRepo) Save(ctx context.Context, evaluation *model.Evaluation) error {
+	r.saves.Add(1)
+	return nil
+}
+
+func (r *countingEvaluationRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Evaluation, error) {
+	return nil, repository.ErrNotFound
+}
+
+// doneAuditRepo 批改结束时（保存批改记录之后）服务总会保存审计记录，以此作为批改结束的信号
+type doneAuditRepo struct {
+	done chan *model.EvaluationAudit
+}
+
+func (r *doneAuditRepo) Save(ctx context.Context, audit *model.EvaluationAudit) error {
+	r.done <- audit
+	return nil
+}
+
+func (r *doneAuditRepo) FindByTraceID(ctx context.Context, traceID string) ([]*model.EvaluationAudit, error) {
+	return nil, nil
+}
+
+// 取消后唯一的终止消息是cancelled：不推送complete，也不保存批改记录
+func TestEvaluateWebSocketCancel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	essayInfo, err := os.ReadFile("../../cmd/mock-upstream/fixtures/essay_info.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 作文信息立即返回，其余步骤挂起直到请求被取消
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/essay_info" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(essayInfo)
+			return
+		}
+		// 读完请求体后服务端才能感知客户端断开
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer upstream.Close()
+
+	cfg := &config.EvaluateConfig{API: config.EvaluateAPIConfig{
+		Overall:      upstream.URL + "/overall",
+		WordSentence: upstream.URL + "/word_sentence",
+		Suggestion:   upstream.URL + "/suggestion",
+		Paragraph:    upstream.URL + "/paragraph",
+		GrammarInfo:  upstream.URL + "/grammar_info",
+		Score:        upstream.URL + "/score",
+		EssayInfo:    upstream.URL + "/essay_info",
+		Polishing:    upstream.URL + "/polishing",
+	}, Audit: config.AuditConfig{Enabled: true}}
+	repo := &countingEvaluationRepo{}
+	auditRepo := &doneAuditRepo{done: make(chan *model.EvaluationAudit, 1)}
+	svc := appService.NewEvaluateServiceV2(cfg, nil, repo, nil, auditRepo)
+	h := NewEvaluateHandler(svc, nil, nil, &config.StreamConfig{PongWait: time.Minute})
+
+	router := gin.New()
+	router.GET("/evaluate/ws", h.EvaluateWebSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/evaluate/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	if err := conn.WriteJSON(map[string]any{
+		"type": "evaluate",
+		"data": map[string]any{"title": "我的妈妈", "content": "每天早上，妈妈总是第一个起床。"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 作文信息完成后并行步骤已开始，此时取消
+	for {
+		var msg model.StreamEvaluateResponse
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("读取消息失败: %v", err)
+		}
+		if msg.Type == "progress" && msg.Step == "essay_info" {
+			break
+		}
+	}
+	if err := conn.WriteJSON(map[string]string{"type": "cancel"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var types []string
+	for {
+		var msg model.StreamEvaluateResponse
+		if err := conn.ReadJSON(&msg); err != nil {
+			break
+		}
+		types = append(types, msg.Type)
+	}
+	if len(types) == 0 || types[len(types)-1] != "cancelled" {
+		t.Fatalf("取消后的消息 = %v，最后一条应为cancelled", types)
+	}
+	for _, typ := range types {
+		if typ == "complete" {
+			t.Fatalf("取消后不应推送complete: %v", types)
+		}
+	}
+
+	// 等服务端批改结束再断言，审计记录在批改记录保存（如有）之后写入
+	select {
+	case audit := <-auditRepo.done:
+		if audit.EvaluationID != "" {
+			t.Fatalf("取消的批改不应有批改记录ID: %s", audit.EvaluationID)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("取消后批改未结束")
+	}
+	if n := repo.saves.Load(); n != 0 {
+		t.Fatalf("取消的批改不应保存，实际保存 %d 次", n)
+	}
+}
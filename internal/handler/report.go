@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+type ReportHandler struct {
+	serviceV2 *appService.ReportServiceV2
+}
+
+func NewReportHandler(serviceV2 *appService.ReportServiceV2) *ReportHandler {
+	return &ReportHandler{
+		serviceV2: serviceV2,
+	}
+}
+
+// EvaluationHTML 根据批改记录ID渲染HTML报告
+func (h *ReportHandler) EvaluationHTML(c *gin.Context) {
+	result, ok := h.loadResult(c)
+	if !ok {
+		return
+	}
+	h.renderHTML(c, result)
+}
+
+// RenderHTML 根据请求体中的EvaluateResponse渲染HTML报告
+func (h *ReportHandler) RenderHTML(c *gin.Context) {
+	var result model.EvaluateResponse
+	if err := c.ShouldBindJSON(&result); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, err.Error()))
+		return
+	}
+	h.renderHTML(c, &result)
+}
+
+func (h *ReportHandler) renderHTML(c *gin.Context, result *model.EvaluateResponse) {
+	var buf bytes.Buffer
+	if err := h.serviceV2.RenderHTML(&buf, result); err != nil {
+		logrus.WithError(err).Error("Failed to render html report")
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(500, "报告生成失败"))
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// EvaluationDOCX 根据批改记录ID导出Word报告
+func (h *ReportHandler) EvaluationDOCX(c *gin.Context) {
+	result, ok := h.loadResult(c)
+	if !ok {
+		return
+	}
+	h.renderDOCX(c, result, c.Param("id"))
+}
+
+// RenderDOCX 根据请求体中的EvaluateResponse导出Word报告
+func (h *ReportHandler) RenderDOCX(c *gin.Context) {
+	var result model.EvaluateResponse
+	if err := c.ShouldBindJSON(&result); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(400, err.Error()))
+		return
+	}
+	h.renderDOCX(c, &result, "report")
+}
+
+func (h *ReportHandler) renderDOCX(c *gin.Context, result *model.EvaluateResponse, name string) {
+	var buf bytes.Buffer
+	if err := h.serviceV2.RenderDOCX(&buf, result); err != nil {
+		logrus.WithError(err).Error("Failed to render docx report")
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(500, "报告生成失败"))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.docx"`, name))
+	c.Data(http.StatusOK, docxContentType, buf.Bytes())
+}
+
+// loadResult 加载当前用户（X-User-ID）的批改结果，失败时已写入错误响应
+func (h *ReportHandler) loadResult(c *gin.Context) (*model.EvaluateResponse, bool) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		userID = "anonymous"
+	}
+
+	result, err := h.serviceV2.GetResult(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.NewErrorResponse(404, "批改记录不存在"))
+			return nil, false
+		}
+		logrus.WithError(err).Error("Failed to get evaluation")
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(500, "Internal server error"))
+		return nil, false
+	}
+	return result, true
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appService "essay-stateless/internal/application/service"
+	"essay-stateless/internal/config"
+	"essay-stateless/internal/model"
+	"essay-stateless/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type singleEvaluationRepo struct {
+	evaluation *model.Evaluation
+}
+
+func (r *singleEvaluationRepo) Save(ctx context.Context, evaluation *model.Evaluation) error {
+	return nil
+}
+
+func (r *singleEvaluationRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Evaluation, error) {
+	if id != r.evaluation.ID {
+		return nil, repository.ErrNotFound
+	}
+	return r.evaluation, nil
+}
+
+// 按ID导出报告只限创建记录的用户，其他用户与不存在的记录一样返回404
+func TestEvaluationReportOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	evaluation := &model.Evaluation{
+		ID:     primitive.NewObjectID(),
+		UserID: "alice",
+		Result: &model.EvaluateResponse{Title: "我的妈妈"},
+	}
+	evaluateService := appService.NewEvaluateServiceV2(&config.EvaluateConfig{}, nil, &singleEvaluationRepo{evaluation: evaluation}, nil, nil)
+	h := NewReportHandler(appService.NewReportServiceV2(evaluateService))
+
+	router := gin.New()
+	router.GET("/evaluate/:id/report.html", h.EvaluationHTML)
+	router.GET("/evaluate/:id/report.docx", h.EvaluationDOCX)
+
+	tests := []struct {
+		name   string
+		path   string
+		userID string
+		want   int
+	}{
+		{"本人导出HTML", "/report.html", "alice", http.StatusOK},
+		{"本人导出Word", "/report.docx", "alice", http.StatusOK},
+		{"其他用户导出HTML", "/report.html", "bob", http.StatusNotFound},
+		{"其他用户导出Word", "/report.docx", "bob", http.StatusNotFound},
+		{"未带用户ID", "/report.html", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/evaluate/"+evaluation.ID.Hex()+tt.path, nil)
+			if tt.userID != "" {
+				req.Header.Set("X-User-ID", tt.userID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("状态码 = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
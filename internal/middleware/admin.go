@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"essay-stateless/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuthMiddleware 管理接口鉴权：校验 X-Admin-Token 请求头，未配置令牌时拒绝所有请求
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader("X-Admin-Token")
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, model.NewErrorResponse(403, "无权访问管理接口"))
+			return
+		}
+		c.Next()
+	}
+}
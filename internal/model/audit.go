@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EvaluationAudit 批改审计记录：一次批改中每个步骤的上游原始请求与响应，按trace ID归档
+type EvaluationAudit struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TraceID      string             `bson:"trace_id" json:"traceId"`
+	EvaluationID string             `bson:"evaluation_id,omitempty" json:"evaluationId,omitempty"` // 批改未完成时为空
+	UserID       string             `bson:"user_id" json:"userId"`
+	Request      *EvaluateRequest   `bson:"request" json:"request"`
+	Exchanges    []AuditExchange    `bson:"exchanges" json:"exchanges"`
+	CreateTime   time.Time          `bson:"create_time" json:"createTime"`
+}
+
+// AuditExchange 一次上游调用（每次重试单独记录）
+type AuditExchange struct {
+	Step         string    `bson:"step" json:"step"`
+	Attempt      int       `bson:"attempt" json:"attempt"`             // 该步骤第几次尝试（重试序号），从1开始
+	Run          int       `bson:"run,omitempty" json:"run,omitempty"` // 集成评分中的第几次调用，从1开始，非集成评分为0
+	URL          string    `bson:"url" json:"url"`
+	RequestBody  string    `bson:"request_body" json:"requestBody"`
+	StatusCode   int       `bson:"status_code" json:"statusCode"`
+	ResponseBody string    `bson:"response_body,omitempty" json:"responseBody,omitempty"`
+	StreamChunks []string  `bson:"stream_chunks,omitempty" json:"streamChunks,omitempty"` // 流式响应（润色）逐条SSE数据
+	Error        string    `bson:"error,omitempty" json:"error,omitempty"`
+	StartTime    time.Time `bson:"start_time" json:"startTime"`
+	LatencyMs    int64     `bson:"latency_ms" json:"latencyMs"`
+}
+
+// AuditBundle 按trace ID查询的审计包：各次批改的上游原始记录与最终结果
+type AuditBundle struct {
+	TraceID string            `json:"traceId"`
+	Items   []AuditBundleItem `json:"items"`
+}
+
+type AuditBundleItem struct {
+	Audit      *EvaluationAudit `json:"audit"`
+	Evaluation *Evaluation      `json:"evaluation,omitempty"`
+}
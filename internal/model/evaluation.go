@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Evaluation 已完成的作文批改记录
+type Evaluation struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     string             `bson:"user_id" json:"userId"`
+	TraceID    string             `bson:"trace_id" json:"traceId"`
+	Request    *EvaluateRequest   `bson:"request" json:"request"`
+	Result     *EvaluateResponse  `bson:"result" json:"result"`
+	CreateTime time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime time.Time          `bson:"update_time" json:"updateTime"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// StepLatency 评估步骤的滚动耗时统计（指数加权）
+type StepLatency struct {
+	Step        string    `bson:"_id" json:"step"`
+	Count       int64     `bson:"count" json:"count"`              // 样本数
+	MeanMs      float64   `bson:"mean_ms" json:"meanMs"`           // 加权平均耗时（毫秒）
+	DeviationMs float64   `bson:"deviation_ms" json:"deviationMs"` // 加权平均绝对偏差（毫秒）
+	UpdateTime  time.Time `bson:"update_time" json:"updateTime"`
+}
@@ -6,17 +6,27 @@ import (
 
 // EvaluateRequest 作文批改请求
 type EvaluateRequest struct {
-	Title            string  `json:"title"`
-	Content          string  `json:"content"`
-	Grade            *int    `json:"grade,omitempty"`
-	EssayType        *string `json:"essayType,omitempty"`
-	TotalScore       *int64  `json:"totalScore,omitempty"`
-	Prompt           *string `json:"prompt,omitempty"` // 题干/写作要求
-	Standard         *string `json:"standard,omitempty"`
-	ContentScore     *int64  `json:"contentScore,omitempty"`
-	ExpressionScore  *int64  `json:"expressionScore,omitempty"`
-	StructureScore   *int64  `json:"structureScore,omitempty"`
-	DevelopmentScore *int64  `json:"developmentScore,omitempty"`
+	Title            string   `json:"title"`
+	Content          string   `json:"content"`
+	Grade            *int     `json:"grade,omitempty"`
+	EssayType        *string  `json:"essayType,omitempty"`
+	TotalScore       *int64   `json:"totalScore,omitempty"`
+	Prompt           *string  `json:"prompt,omitempty"`   // 题干/写作要求
+	Standard         *string  `json:"standard,omitempty"` // 自由文本评分标准
+	Rubric           *Rubric  `json:"rubric,omitempty"`   // 结构化评分量表，与rubricId二选一
+	RubricID         *string  `json:"rubricId,omitempty"` // 引用配置中的评分量表
+	ContentScore     *int64   `json:"contentScore,omitempty"`
+	ExpressionScore  *int64   `json:"expressionScore,omitempty"`
+	StructureScore   *int64   `json:"structureScore,omitempty"`
+	DevelopmentScore *int64   `json:"developmentScore,omitempty"`
+	Images           []string `json:"images,omitempty"`          // 手写原稿图片（URL或base64），提供时评分接口给出卷面分
+	AppearanceScore  *int64   `json:"appearanceScore,omitempty"` // 卷面满分，仅在提供images时计入
+	ReferenceEssay   *string  `json:"referenceEssay,omitempty"`  // 范文，提供时与范文对比，段落以换行分隔
+}
+
+// HasImages 是否提供了原稿图片，卷面分只在有图片时评分和计入总分
+func (r *EvaluateRequest) HasImages() bool {
+	return len(r.Images) > 0
 }
 
 func (r *EvaluateRequest) JSONString() string {
@@ -32,6 +42,10 @@ type OcrEvaluateRequest struct {
 	ImageType *string  `json:"imageType,omitempty"`
 	Grade     *int     `json:"grade,omitempty"`
 	EssayType *string  `json:"essayType,omitempty"`
+	// 卷面满分，识别用的原稿图片会一并用于卷面评分
+	AppearanceScore *int64 `json:"appearanceScore,omitempty"`
+	// 范文，提供时与范文对比
+	ReferenceEssay *string `json:"referenceEssay,omitempty"`
 }
 
 func (r *OcrEvaluateRequest) JSONString() string {
@@ -42,6 +42,10 @@ type EssayInfo struct {
 	Grade     int      `json:"grade"`
 	Counting  Counting `json:"counting"`
 	AllScore  int64    `json:"score"`
+
+	Source      string   `json:"source,omitempty"`      // local 表示作文信息服务不可用，分段分句和计数由本地估算
+	Approximate []string `json:"approximate,omitempty"` // 本地估算、与作文信息服务口径不同的计数项
+	Unavailable []string `json:"unavailable,omitempty"` // 本地无法计算、置为0的计数项
 }
 
 type Counting struct {
@@ -69,6 +73,68 @@ type AIEvaluation struct {
 	ParagraphEvaluations   []ParagraphEvaluation  `json:"paragraphEvaluations,omitempty"`   // 段落点评
 	ScoreEvaluation        ScoreEvaluation        `json:"scoreEvaluations,omitempty"`       // 分数点评
 	PolishingEvaluation    []PolishingEvaluation  `json:"polishingEvaluation,omitempty"`    // 润色点评
+	RequirementCompliance  *RequirementCompliance `json:"requirementCompliance,omitempty"`  // 题干要求符合情况，请求未提供题干或题干中没有可识别的要求时为空
+	GenreEvaluation        *GenreEvaluation       `json:"genreEvaluation,omitempty"`        // 文体专项检查，未匹配到文体配置时为空
+	ReferenceComparison    *ReferenceComparison   `json:"referenceComparison,omitempty"`    // 与范文的对比，未提供范文时为空
+}
+
+// ReferenceComparison 与范文的对比
+type ReferenceComparison struct {
+	Similarity    float64             `json:"similarity"` // 相似度 0~100，按字词重合度计算
+	Structure     StructureComparison `json:"structure"`
+	SharedPhrases []string            `json:"sharedPhrases"` // 两文共有的关键短语
+	Covered       []ReferencePoint    `json:"covered"`       // 作文写到的范文要点
+	Missed        []ReferencePoint    `json:"missed"`        // 作文没有写到的范文要点
+}
+
+// StructureComparison 篇章结构对比
+type StructureComparison struct {
+	ParagraphCount          int      `json:"paragraphCount"`
+	ReferenceParagraphCount int      `json:"referenceParagraphCount"`
+	CharCount               int      `json:"charCount"`
+	ReferenceCharCount      int      `json:"referenceCharCount"`
+	ParagraphLengths        []int    `json:"paragraphLengths"`          // 作文各段字数
+	ReferenceLengths        []int    `json:"referenceParagraphLengths"` // 范文各段字数
+	Differences             []string `json:"differences"`               // 结构差异说明
+}
+
+// ReferencePoint 范文要点（范文的一个段落）
+type ReferencePoint struct {
+	ReferenceParagraphIndex int      `json:"referenceParagraphIndex"`
+	Summary                 string   `json:"summary"` // 范文该段首句
+	KeyPhrases              []string `json:"keyPhrases,omitempty"`
+	Coverage                float64  `json:"coverage"`       // 作文中最接近的段落对该段的覆盖率 0~1
+	ParagraphIndex          int      `json:"paragraphIndex"` // 作文中最接近的段落，-1表示没有
+}
+
+// GenreEvaluation 按文体配置进行的专项检查
+type GenreEvaluation struct {
+	Profile   string       `json:"profile"`   // 使用的文体配置
+	EssayType string       `json:"essayType"` // 作文文体
+	Checks    []GenreCheck `json:"checks,omitempty"`
+}
+
+// GenreCheck 单项文体专项检查
+type GenreCheck struct {
+	Kind     string        `json:"kind"` // thesis / argument / explanation_method / narrative_elements
+	Name     string        `json:"name"` // 中心论点、论据、说明方法、记叙要素
+	Passed   bool          `json:"passed"`
+	Detail   string        `json:"detail"`
+	Evidence []EvidenceRef `json:"evidence,omitempty"` // 原文中的依据
+}
+
+// RequirementCompliance 题干要求符合情况
+type RequirementCompliance struct {
+	AllMet       bool               `json:"allMet"`
+	Requirements []RequirementCheck `json:"requirements"`
+}
+
+// RequirementCheck 单项题干要求的检查结果
+type RequirementCheck struct {
+	Kind        string `json:"kind"`        // word_count / genre / title / perspective
+	Requirement string `json:"requirement"` // 从题干中识别出的要求
+	Met         bool   `json:"met"`
+	Evidence    string `json:"evidence"` // 判定依据
 }
 
 type ModelVersion struct {
@@ -187,9 +253,80 @@ type ParagraphEvaluation struct {
 }
 
 type ScoreEvaluation struct {
-	Comment  string   `json:"comment"`
-	Comments Comments `json:"comments"`
-	Scores   Scores   `json:"scores"`
+	Comment        string                   `json:"comment"`
+	Comments       Comments                 `json:"comments"`
+	Scores         Scores                   `json:"scores"`
+	ExamBand       *ExamBandEvaluation      `json:"examBand,omitempty"`       // 考试评分标准分类，未配置标准时为空
+	Criteria       []CriterionScore         `json:"criteria,omitempty"`       // 按评分量表的分项得分，请求未带量表时为空
+	Adjustments    []ScoreAdjustment        `json:"adjustments,omitempty"`    // 对上游分数所做的校正，未校正时为空
+	OffTopic       bool                     `json:"offTopic"`                 // 切题度低于阈值，已按偏题/离题限分
+	OffTopicReason string                   `json:"offTopicReason,omitempty"` // 偏题判定与限分说明
+	Confidence     *ScoreConfidence         `json:"confidence,omitempty"`     // 集成评分各次结果的一致性，未启用集成评分时为空
+	Evidence       map[string][]EvidenceRef `json:"evidence,omitempty"`       // 各维度评语的原文依据，key同comments（content/expression/structure/development）
+}
+
+// EvidenceRef 评语在原文中的依据
+type EvidenceRef struct {
+	Source         string `json:"source"`         // quote 评语引用原文 / good_sentence 好句 / good_word 好词 / mistake 还需努力 / paragraph_comment 段落点评 / sentence 文体专项检查命中的句子
+	Polarity       string `json:"polarity"`       // positive / negative
+	ParagraphIndex int    `json:"paragraphIndex"` // 段落下标
+	SentenceIndex  int    `json:"sentenceIndex"`  // 句子下标，-1表示整段
+	Span           []int  `json:"span,omitempty"` // 句内字符区间 [start, end)，与wordEvaluations一致
+	Text           string `json:"text,omitempty"` // 依据原文
+	Note           string `json:"note,omitempty"` // 好句标签、错误类型或段落点评
+}
+
+// ScoreConfidence 集成评分的一致性
+type ScoreConfidence struct {
+	Runs          int           `json:"runs"`      // 成功的评分次数
+	Failed        int           `json:"failed"`    // 失败的评分次数
+	Aggregate     string        `json:"aggregate"` // median / trimmed_mean
+	Spreads       []ScoreSpread `json:"spreads"`
+	LowConfidence bool          `json:"lowConfidence"` // 各次评分分歧过大，建议教师复核
+	Reason        string        `json:"reason,omitempty"`
+}
+
+// ScoreSpread 单项分数在各次评分中的分布
+type ScoreSpread struct {
+	Field      string  `json:"field"` // all/content/expression/structure/development/appearance，评分项为 criterion:<评分项ID>
+	Values     []int64 `json:"values"`
+	Aggregated int64   `json:"aggregated"`
+	Spread     int64   `json:"spread"` // 极差
+	StdDev     float64 `json:"stdDev"`
+}
+
+// ScoreAdjustment 一次分数校正
+type ScoreAdjustment struct {
+	Field    string `json:"field"` // all/content/expression/structure/development
+	Kind     string `json:"kind"`  // rescale 分制折算 / clamp 越界截断 / reconcile 总分与各维度之和对齐 / mismatch 不一致但按策略保留 / off_topic 偏题限分
+	Original int64  `json:"original"`
+	Adjusted int64  `json:"adjusted"`
+	Reason   string `json:"reason"`
+}
+
+// ExamBandEvaluation 按考试评分标准的分类结果
+type ExamBandEvaluation struct {
+	Standard     string                `json:"standard"`
+	StandardName string                `json:"standardName"`
+	Band         *BandResult           `json:"band,omitempty"`       // 总分类别，如 一类文
+	Dimensions   []DimensionBandResult `json:"dimensions,omitempty"` // 各维度等级，如 基础等级-内容 一等
+}
+
+// BandResult 归入的类别/等级及依据
+type BandResult struct {
+	Name       string   `json:"name"`
+	Descriptor string   `json:"descriptor"`
+	Score      int64    `json:"score"`
+	Total      int64    `json:"total"`
+	Ratio      float64  `json:"ratio"` // 得分率 0~1
+	Reasons    []string `json:"reasons"`
+}
+
+// DimensionBandResult 单个维度的等级
+type DimensionBandResult struct {
+	Dimension string `json:"dimension"`
+	Group     string `json:"group,omitempty"`
+	BandResult
 }
 
 type Comments struct {
@@ -213,6 +350,7 @@ type Scores struct {
 	ExpressionWithTotal  string `json:"expressionWithTotal"`
 	StructureWithTotal   string `json:"structureWithTotal"`
 	DevelopmentWithTotal string `json:"developmentWithTotal"`
+	AppearanceWithTotal  string `json:"appearanceWithTotal,omitempty"` // 仅在提供原稿图片时给出
 }
 
 type PolishingEvaluation struct {
@@ -257,19 +395,24 @@ func (r *DefaultOcrResponse) JSONString() (string, error) {
 
 // StreamEvaluateResponse 流式评估响应
 type StreamEvaluateResponse struct {
-	Type      string `json:"type"`      // 响应类型: "init", "progress", "complete", "error"
-	Step      string `json:"step"`      // 当前步骤
-	Progress  int    `json:"progress"`  // 进度百分比 (0-100)
-	Data      any    `json:"data"`      // 具体数据
-	Message   string `json:"message"`   // 状态消息
-	Timestamp int64  `json:"timestamp"` // 时间戳
+	Type       string `json:"type"`       // 响应类型: "init", "progress", "step_error", "warning", "complete", "error"
+	Step       string `json:"step"`       // 当前步骤
+	Progress   int    `json:"progress"`   // 进度百分比 (0-100)
+	ETASeconds int    `json:"etaSeconds"` // 预计剩余秒数（基于历史步骤耗时）
+	Data       any    `json:"data"`       // 具体数据
+	Message    string `json:"message"`    // 状态消息
+	Timestamp  int64  `json:"timestamp"`  // 时间戳
+	// 批改记录ID，仅complete消息携带，可通过 GET /evaluate/:id 查询
+	EvaluationID string `json:"evaluationId,omitempty"`
 }
 
 // StreamInitData 初始化数据
 type StreamInitData struct {
-	Title     string     `json:"title"`
-	Text      [][]string `json:"text"`
-	EssayInfo EssayInfo  `json:"essay_info"` // 这里要改下todo
+	Title                 string                 `json:"title"`
+	Text                  [][]string             `json:"text"`
+	EssayInfo             EssayInfo              `json:"essay_info"` // 这里要改下todo
+	RequirementCompliance *RequirementCompliance `json:"requirementCompliance,omitempty"`
+	GenreEvaluation       *GenreEvaluation       `json:"genreEvaluation,omitempty"`
 }
 
 // StreamStepData 步骤完成数据
@@ -285,8 +428,11 @@ type StreamCompleteData struct {
 
 // StreamErrorData 错误数据
 type StreamErrorData struct {
-	Error string `json:"error"`
-	Step  string `json:"step"`
+	Error    string `json:"error"`
+	Step     string `json:"step"`
+	Kind     string `json:"kind,omitempty"`     // transport/business/invalid/canceled
+	Code     string `json:"code,omitempty"`     // 上游业务code或HTTP状态码
+	Attempts int    `json:"attempts,omitempty"` // 实际尝试次数
 }
 
 // JSONString 序列化流式响应
@@ -0,0 +1,38 @@
+package model
+
+// Rubric 结构化评分量表
+type Rubric struct {
+	ID       string            `json:"id,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Criteria []RubricCriterion `json:"criteria"`
+}
+
+// RubricCriterion 评分项
+type RubricCriterion struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Dimension   string        `json:"dimension,omitempty"` // 所属维度 content/expression/structure/development/appearance，为空表示不属于任何维度
+	MaxPoints   int64         `json:"maxPoints"`
+	Levels      []RubricLevel `json:"levels,omitempty"` // 档次描述
+}
+
+// RubricLevel 评分项的档次：得分不低于MinPoints即归入
+type RubricLevel struct {
+	Name       string `json:"name"`
+	MinPoints  int64  `json:"minPoints"`
+	Descriptor string `json:"descriptor,omitempty"`
+}
+
+// CriterionScore 评分项得分
+type CriterionScore struct {
+	CriterionID     string `json:"criterionId"`
+	Name            string `json:"name"`
+	Dimension       string `json:"dimension,omitempty"`
+	Score           int64  `json:"score"`
+	MaxPoints       int64  `json:"maxPoints"`
+	Level           string `json:"level,omitempty"`
+	LevelDescriptor string `json:"levelDescriptor,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	Estimated       bool   `json:"estimated"` // 上游未给出该项得分，按维度分数和分值比例折算
+}
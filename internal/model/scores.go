@@ -20,5 +20,17 @@ type APIScore struct {
 			Structure   int64 `json:"structure"`
 			Development int64 `json:"development"`
 		} `json:"scores"`
+		// 支持结构化量表的上游按评分项返回得分
+		Criteria []APIScoreCriterion `json:"criteria"`
 	} `json:"result"`
+
+	// 集成评分时由各次结果计算，不是上游返回的字段
+	Confidence *ScoreConfidence `json:"-"`
+}
+
+// APIScoreCriterion 上游返回的单个评分项得分
+type APIScoreCriterion struct {
+	ID      string `json:"id"`
+	Score   int64  `json:"score"`
+	Comment string `json:"comment"`
 }
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ShadowEvaluation 影子流量对比记录：同一请求在主上游与候选上游的步骤结果并列保存
+type ShadowEvaluation struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TraceID     string             `bson:"trace_id" json:"traceId"`
+	Candidate   string             `bson:"candidate" json:"candidate"` // 候选模型名称
+	Request     *EvaluateRequest   `bson:"request" json:"request"`
+	Steps       []ShadowStepResult `bson:"steps" json:"steps"`
+	ScoreDeltas []ShadowScoreDelta `bson:"score_deltas" json:"scoreDeltas"`
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+}
+
+// ShadowStepResult 单个步骤的主/候选上游原始结果
+type ShadowStepResult struct {
+	Step             string `bson:"step" json:"step"`
+	Primary          any    `bson:"primary,omitempty" json:"primary,omitempty"`
+	Shadow           any    `bson:"shadow,omitempty" json:"shadow,omitempty"`
+	PrimaryError     string `bson:"primary_error,omitempty" json:"primaryError,omitempty"`
+	ShadowError      string `bson:"shadow_error,omitempty" json:"shadowError,omitempty"`
+	PrimaryLatencyMs int64  `bson:"primary_latency_ms" json:"primaryLatencyMs"`
+	ShadowLatencyMs  int64  `bson:"shadow_latency_ms" json:"shadowLatencyMs"`
+}
+
+// ShadowScoreDelta 分数差异（候选 - 主）
+type ShadowScoreDelta struct {
+	Name    string `bson:"name" json:"name"`
+	Primary int64  `bson:"primary" json:"primary"`
+	Shadow  int64  `bson:"shadow" json:"shadow"`
+	Delta   int64  `bson:"delta" json:"delta"`
+}
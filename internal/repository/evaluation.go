@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"essay-stateless/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrNotFound = errors.New("document not found")
+
+type EvaluationRepository interface {
+	Save(ctx context.Context, evaluation *model.Evaluation) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.Evaluation, error)
+}
+
+type evaluationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewEvaluationRepository(db *mongo.Database) EvaluationRepository {
+	return &evaluationRepository{
+		collection: db.Collection("evaluations"),
+	}
+}
+
+func (r *evaluationRepository) Save(ctx context.Context, evaluation *model.Evaluation) error {
+	_, err := r.collection.InsertOne(ctx, evaluation)
+	return err
+}
+
+func (r *evaluationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Evaluation, error) {
+	var evaluation model.Evaluation
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&evaluation); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &evaluation, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"essay-stateless/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EvaluationAuditRepository interface {
+	Save(ctx context.Context, audit *model.EvaluationAudit) error
+	FindByTraceID(ctx context.Context, traceID string) ([]*model.EvaluationAudit, error)
+}
+
+type evaluationAuditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewEvaluationAuditRepository(db *mongo.Database) EvaluationAuditRepository {
+	return &evaluationAuditRepository{
+		collection: db.Collection("evaluation_audits"),
+	}
+}
+
+func (r *evaluationAuditRepository) Save(ctx context.Context, audit *model.EvaluationAudit) error {
+	_, err := r.collection.InsertOne(ctx, audit)
+	return err
+}
+
+func (r *evaluationAuditRepository) FindByTraceID(ctx context.Context, traceID string) ([]*model.EvaluationAudit, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"trace_id": traceID}, options.Find().SetSort(bson.D{{Key: "create_time", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var audits []*model.EvaluationAudit
+	if err := cursor.All(ctx, &audits); err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
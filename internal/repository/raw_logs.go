@@ -3,12 +3,25 @@ package repository
 import (
 	"context"
 	"essay-stateless/internal/model"
+	"regexp"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type RawLogsRepository interface {
 	Save(ctx context.Context, log *model.RawLogs) error
+	Find(ctx context.Context, filter RawLogsFilter) ([]*model.RawLogs, error)
+}
+
+// RawLogsFilter 原始日志查询条件
+type RawLogsFilter struct {
+	URLPrefix string    // URL前缀，如 /evaluate/stream、/sts/ocr/title/
+	Start     time.Time // 起始时间（含），零值不限制
+	End       time.Time // 结束时间（不含），零值不限制
+	Limit     int64     // 最大条数，0不限制
 }
 
 type rawLogsRepository struct {
@@ -25,3 +38,38 @@ func (r *rawLogsRepository) Save(ctx context.Context, log *model.RawLogs) error
 	_, err := r.collection.InsertOne(ctx, log)
 	return err
 }
+
+// Find 按URL前缀和时间范围查询，按创建时间升序
+func (r *rawLogsRepository) Find(ctx context.Context, filter RawLogsFilter) ([]*model.RawLogs, error) {
+	query := bson.M{}
+	if filter.URLPrefix != "" {
+		query["url"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.URLPrefix)}
+	}
+
+	createTime := bson.M{}
+	if !filter.Start.IsZero() {
+		createTime["$gte"] = filter.Start
+	}
+	if !filter.End.IsZero() {
+		createTime["$lt"] = filter.End
+	}
+	if len(createTime) > 0 {
+		query["create_time"] = createTime
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*model.RawLogs
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
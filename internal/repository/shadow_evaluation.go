@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"essay-stateless/internal/model"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ShadowEvaluationRepository interface {
+	Save(ctx context.Context, evaluation *model.ShadowEvaluation) error
+}
+
+type shadowEvaluationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewShadowEvaluationRepository(db *mongo.Database) ShadowEvaluationRepository {
+	return &shadowEvaluationRepository{
+		collection: db.Collection("shadow_evaluations"),
+	}
+}
+
+func (r *shadowEvaluationRepository) Save(ctx context.Context, evaluation *model.ShadowEvaluation) error {
+	_, err := r.collection.InsertOne(ctx, evaluation)
+	return err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"essay-stateless/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StepLatencyRepository interface {
+	FindAll(ctx context.Context) ([]model.StepLatency, error)
+	Upsert(ctx context.Context, latency *model.StepLatency) error
+}
+
+type stepLatencyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStepLatencyRepository(db *mongo.Database) StepLatencyRepository {
+	return &stepLatencyRepository{
+		collection: db.Collection("step_latency_stats"),
+	}
+}
+
+func (r *stepLatencyRepository) FindAll(ctx context.Context) ([]model.StepLatency, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var latencies []model.StepLatency
+	if err := cursor.All(ctx, &latencies); err != nil {
+		return nil, err
+	}
+	return latencies, nil
+}
+
+func (r *stepLatencyRepository) Upsert(ctx context.Context, latency *model.StepLatency) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": latency.Step}, latency, options.Replace().SetUpsert(true))
+	return err
+}
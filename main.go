@@ -4,13 +4,16 @@ import (
 	"context"
 	appService "essay-stateless/internal/application/service"
 	"essay-stateless/internal/config"
+	"essay-stateless/internal/grpcserver"
 	"essay-stateless/internal/handler"
 	"essay-stateless/internal/middleware"
 	"essay-stateless/internal/repository"
 	"essay-stateless/pkg/database"
+	"essay-stateless/pkg/httpclient"
 	"essay-stateless/pkg/logger"
 	"essay-stateless/pkg/trace"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -25,6 +28,10 @@ func main() {
 	cfg := config.Load()
 	logger.Init(cfg.Log)
 
+	if err := httpclient.UseCassettes(cfg.Cassette.Mode, cfg.Cassette.Dir); err != nil {
+		log.Fatal("Failed to initialize http cassettes:", err)
+	}
+
 	// 初始化追踪
 	shutdown, err := trace.Init(cfg.Trace)
 	if err != nil {
@@ -39,18 +46,26 @@ func main() {
 	defer db.Disconnect()
 
 	rawLogsRepo := repository.NewRawLogsRepository(db.Database())
+	stepLatencyRepo := repository.NewStepLatencyRepository(db.Database())
+	evaluationRepo := repository.NewEvaluationRepository(db.Database())
+	shadowEvaluationRepo := repository.NewShadowEvaluationRepository(db.Database())
+	evaluationAuditRepo := repository.NewEvaluationAuditRepository(db.Database())
 
 	// 初始化新版服务（基于DDD架构）
-	evaluateServiceV2 := appService.NewEvaluateServiceV2(&cfg.Evaluate)
+	evaluateServiceV2 := appService.NewEvaluateServiceV2(&cfg.Evaluate, stepLatencyRepo, evaluationRepo, shadowEvaluationRepo, evaluationAuditRepo)
 	ocrServiceV2 := appService.NewOcrServiceV2(&cfg.OCR)
 	statisticsServiceV2 := appService.NewStatisticsServiceV2()
+	reportServiceV2 := appService.NewReportServiceV2(evaluateServiceV2)
+	auditServiceV2 := appService.NewAuditServiceV2(evaluationAuditRepo, evaluationRepo)
 
 	// 初始化Handler（使用新版服务）
-	evaluateHandler := handler.NewEvaluateHandler(evaluateServiceV2, rawLogsRepo)
+	evaluateHandler := handler.NewEvaluateHandler(evaluateServiceV2, ocrServiceV2, rawLogsRepo, &cfg.Server.Stream)
 	ocrHandler := handler.NewOcrHandler(ocrServiceV2, rawLogsRepo)
 	statisticsHandler := handler.NewStatisticsHandler(statisticsServiceV2, rawLogsRepo)
+	reportHandler := handler.NewReportHandler(reportServiceV2)
+	adminHandler := handler.NewAdminHandler(auditServiceV2)
 
-	router := setupRouter(evaluateHandler, ocrHandler, statisticsHandler)
+	router := setupRouter(evaluateHandler, ocrHandler, statisticsHandler, reportHandler, adminHandler, cfg.Server.AdminToken)
 
 	server := &http.Server{
 		Addr:    cfg.Server.Port,
@@ -63,6 +78,20 @@ func main() {
 		}
 	}()
 
+	// gRPC服务（与HTTP共用同一服务层）
+	grpcServer := grpcserver.NewGRPCServer(grpcserver.NewServer(evaluateServiceV2, ocrServiceV2, statisticsServiceV2, rawLogsRepo))
+	if cfg.Server.GRPCPort != "" {
+		lis, err := net.Listen("tcp", cfg.Server.GRPCPort)
+		if err != nil {
+			log.Fatal("Failed to listen grpc port:", err)
+		}
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatal("Failed to start grpc server:", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -70,6 +99,8 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	grpcServer.GracefulStop()
+
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
 	}
@@ -77,7 +108,7 @@ func main() {
 	log.Println("Server exited")
 }
 
-func setupRouter(evaluateHandler *handler.EvaluateHandler, ocrHandler *handler.OcrHandler, statisticsHandler *handler.StatisticsHandler) *gin.Engine {
+func setupRouter(evaluateHandler *handler.EvaluateHandler, ocrHandler *handler.OcrHandler, statisticsHandler *handler.StatisticsHandler, reportHandler *handler.ReportHandler, adminHandler *handler.AdminHandler, adminToken string) *gin.Engine {
 	router := gin.New()
 
 	router.Use(gin.Recovery())
@@ -88,6 +119,13 @@ func setupRouter(evaluateHandler *handler.EvaluateHandler, ocrHandler *handler.O
 	v1 := router.Group("/evaluate")
 	{
 		v1.POST("/stream", evaluateHandler.EvaluateStream)
+		v1.POST("/ocr/stream", evaluateHandler.OcrEvaluateStream)
+		v1.GET("/ws", evaluateHandler.EvaluateWebSocket)
+		v1.GET("/:id", evaluateHandler.GetEvaluation)
+		v1.GET("/:id/report.html", reportHandler.EvaluationHTML)
+		v1.POST("/report.html", reportHandler.RenderHTML)
+		v1.GET("/:id/report.docx", reportHandler.EvaluationDOCX)
+		v1.POST("/report.docx", reportHandler.RenderDOCX)
 	}
 
 	sts := router.Group("/sts")
@@ -100,5 +138,10 @@ func setupRouter(evaluateHandler *handler.EvaluateHandler, ocrHandler *handler.O
 		statistics.POST("/class", statisticsHandler.AnalyzeClassStatistics)
 	}
 
+	admin := router.Group("/admin", middleware.AdminAuthMiddleware(adminToken))
+	{
+		admin.GET("/audit/:traceId", adminHandler.GetAudit)
+	}
+
 	return router
 }
@@ -0,0 +1,316 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 录制/回放模式
+const (
+	CassetteModeOff    = ""       // 直接访问网络
+	CassetteModeRecord = "record" // 访问网络并把请求/响应写入cassette
+	CassetteModeReplay = "replay" // 只从cassette返回响应，不访问网络
+)
+
+// ErrCassetteNotFound 回放模式下没有匹配的录制记录
+var ErrCassetteNotFound = errors.New("cassette not found")
+
+// 写入cassette时脱敏的请求头
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"X-App-Key":     true,
+	"X-App-Secret":  true,
+}
+
+var (
+	cassetteMu        sync.RWMutex
+	cassetteTransport *CassetteTransport
+)
+
+// UseCassettes 设置全局录制/回放模式，需在创建客户端之前调用
+func UseCassettes(mode, dir string) error {
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+
+	switch mode {
+	case CassetteModeOff:
+		cassetteTransport = nil
+		return nil
+	case CassetteModeRecord, CassetteModeReplay:
+	default:
+		return fmt.Errorf("unknown cassette mode: %s", mode)
+	}
+
+	if dir == "" {
+		return errors.New("cassette dir is required")
+	}
+	if mode == CassetteModeRecord {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create cassette dir: %w", err)
+		}
+	}
+
+	cassetteTransport = NewCassetteTransport(mode, dir, http.DefaultTransport)
+	logrus.Infof("HTTP cassette mode: %s, dir: %s", mode, dir)
+	return nil
+}
+
+// baseTransport 客户端使用的底层Transport，开启录制/回放时为CassetteTransport
+func baseTransport() http.RoundTripper {
+	cassetteMu.RLock()
+	defer cassetteMu.RUnlock()
+
+	if cassetteTransport != nil {
+		return cassetteTransport
+	}
+	return http.DefaultTransport
+}
+
+// Cassette 同一请求的录制记录，按录制顺序回放
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction 一次请求/响应
+type Interaction struct {
+	Request    RecordedRequest  `json:"request"`
+	Response   RecordedResponse `json:"response"`
+	RecordedAt time.Time        `json:"recorded_at"`
+}
+
+// RecordedRequest 录制的请求
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+// RecordedResponse 录制的响应，SSE响应保存完整的事件流文本
+type RecordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+// CassetteTransport 录制/回放RoundTripper
+//
+// 请求按 method + URL + 规范化后的JSON请求体 匹配，同一请求多次录制时依次回放，
+// 超出录制次数后重复最后一条。
+type CassetteTransport struct {
+	mode string
+	dir  string
+	next http.RoundTripper
+
+	mu     sync.Mutex
+	played map[string]int
+}
+
+// NewCassetteTransport 创建录制/回放Transport
+func NewCassetteTransport(mode, dir string, next http.RoundTripper) *CassetteTransport {
+	return &CassetteTransport{
+		mode:   mode,
+		dir:    dir,
+		next:   next,
+		played: make(map[string]int),
+	}
+}
+
+// RoundTrip 实现http.RoundTripper
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	name := t.cassetteName(req, body)
+
+	if t.mode == CassetteModeReplay {
+		return t.replay(req, name)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: flattenHeaders(req.Header, true),
+			Body:    string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    flattenHeaders(resp.Header, false),
+		},
+	}
+	// 响应体边读边录，流式响应读完（或关闭）后再写入cassette
+	resp.Body = &recordingBody{
+		body: resp.Body,
+		save: func(data []byte) {
+			interaction.Response.Body = string(data)
+			interaction.RecordedAt = time.Now()
+			if err := t.append(name, interaction); err != nil {
+				logrus.WithError(err).Errorf("Failed to write cassette %s", name)
+			}
+		},
+	}
+	return resp, nil
+}
+
+// replay 按录制顺序返回响应
+func (t *CassetteTransport) replay(req *http.Request, name string) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cassette, err := t.load(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(cassette.Interactions) == 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteNotFound, req.Method, req.URL)
+	}
+
+	index := min(t.played[name], len(cassette.Interactions)-1)
+	t.played[name]++
+	recorded := cassette.Interactions[index].Response
+
+	header := make(http.Header, len(recorded.Headers))
+	for k, v := range recorded.Headers {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// append 追加一条录制记录
+func (t *CassetteTransport) append(name string, interaction Interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cassette, err := t.load(name)
+	if err != nil && !errors.Is(err, ErrCassetteNotFound) {
+		return err
+	}
+	cassette.Interactions = append(cassette.Interactions, interaction)
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0o644)
+}
+
+func (t *CassetteTransport) load(name string) (*Cassette, error) {
+	cassette := &Cassette{}
+	data, err := os.ReadFile(filepath.Join(t.dir, name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cassette, fmt.Errorf("%w: %s", ErrCassetteNotFound, name)
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", name, err)
+	}
+	return cassette, nil
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// cassetteName 文件名由可读的host/path前缀和请求指纹组成
+func (t *CassetteTransport) cassetteName(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	h.Write(canonicalBody(body))
+
+	prefix := strings.Trim(unsafeNameChars.ReplaceAllString(req.URL.Host+req.URL.Path, "_"), "_")
+	if len(prefix) > 80 {
+		prefix = prefix[:80]
+	}
+	return prefix + "-" + hex.EncodeToString(h.Sum(nil))[:16] + ".json"
+}
+
+// canonicalBody JSON请求体重新序列化（对象键有序），避免map遍历顺序影响匹配
+func canonicalBody(body []byte) []byte {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return canonical
+}
+
+func flattenHeaders(header http.Header, redact bool) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(header))
+	for k, v := range header {
+		key := http.CanonicalHeaderKey(k)
+		if redact && redactedHeaders[key] {
+			result[key] = "REDACTED"
+			continue
+		}
+		result[key] = strings.Join(v, ", ")
+	}
+	return result
+}
+
+// recordingBody 读取响应体的同时保存副本，读到EOF或关闭时回调一次
+type recordingBody struct {
+	body io.ReadCloser
+	buf  bytes.Buffer
+	save func([]byte)
+	once sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF {
+		b.once.Do(func() { b.save(b.buf.Bytes()) })
+	}
+	return n, err
+}
+
+// Close 调用方提前结束读取时（如收到SSE end事件或请求取消）只保存已读到的内容，
+// 不再读取剩余响应，避免关闭时阻塞在仍未结束的上游流上
+func (b *recordingBody) Close() error {
+	b.once.Do(func() { b.save(b.buf.Bytes()) })
+	return b.body.Close()
+}
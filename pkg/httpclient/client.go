@@ -21,13 +21,37 @@ type Client struct {
 
 func New() *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout:   300 * time.Second,
-			Transport: otelhttp.NewTransport(http.DefaultTransport),
-		},
+		httpClient: NewHTTPClient(),
 	}
 }
 
+// NewHTTPClient 创建带链路追踪的http.Client，开启录制/回放时经过CassetteTransport
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   300 * time.Second,
+		Transport: otelhttp.NewTransport(baseTransport()),
+	}
+}
+
+// StatusError 上游返回非200状态码
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// StreamEventError 流式响应中收到非content/end事件（上游中途报错）
+type StreamEventError struct {
+	Event string
+}
+
+func (e *StreamEventError) Error() string {
+	return fmt.Sprintf("服务器错误: %s", e.Event)
+}
+
 func readResponseBodyForError(body io.ReadCloser, maxLength int) string {
 	if maxLength <= 0 {
 		maxLength = 1024 // 默认最大1KB
@@ -41,12 +65,15 @@ func readResponseBodyForError(body io.ReadCloser, maxLength int) string {
 	return string(content)
 }
 
-func (c *Client) Post(ctx context.Context, url string, data map[string]any, result any) error {
+func (c *Client) Post(ctx context.Context, url string, data map[string]any, result any) (err error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request data: %w", err)
 	}
 
+	capture := startExchange(ctx, http.MethodPost, url, jsonData)
+	defer func() { capture.finish(ctx, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -60,24 +87,31 @@ func (c *Client) Post(ctx context.Context, url string, data map[string]any, resu
 	}
 	defer resp.Body.Close()
 
+	capture.status(resp.StatusCode)
 	if resp.StatusCode != http.StatusOK {
-		bodyContent := readResponseBodyForError(resp.Body, 1024)
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, bodyContent)
+		body := readResponseBodyForError(resp.Body, 1024)
+		capture.errorBody(body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: body}
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+	// 在关闭响应体的defer之后注册，先于关闭执行，记录中才包含解码器未读取的剩余内容
+	defer capture.drain()
+	if err := json.NewDecoder(capture.reader(resp.Body)).Decode(result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) PostWithHeaders(ctx context.Context, url string, data any, result any, headers map[string]string) error {
+func (c *Client) PostWithHeaders(ctx context.Context, url string, data any, result any, headers map[string]string) (err error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request data: %w", err)
 	}
 
+	capture := startExchange(ctx, http.MethodPost, url, jsonData)
+	defer func() { capture.finish(ctx, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -95,24 +129,31 @@ func (c *Client) PostWithHeaders(ctx context.Context, url string, data any, resu
 	}
 	defer resp.Body.Close()
 
+	capture.status(resp.StatusCode)
 	if resp.StatusCode != http.StatusOK {
-		bodyContent := readResponseBodyForError(resp.Body, 1024)
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, bodyContent)
+		body := readResponseBodyForError(resp.Body, 1024)
+		capture.errorBody(body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: body}
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+	// 在关闭响应体的defer之后注册，先于关闭执行，记录中才包含解码器未读取的剩余内容
+	defer capture.drain()
+	if err := json.NewDecoder(capture.reader(resp.Body)).Decode(result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) PostWithStream(ctx context.Context, url string, headers map[string]string, data map[string]any, resultChan chan<- string) error {
+func (c *Client) PostWithStream(ctx context.Context, url string, headers map[string]string, data map[string]any, resultChan chan<- string) (err error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request data: %w", err)
 	}
 
+	capture := startExchange(ctx, http.MethodPost, url, jsonData)
+	defer func() { capture.finish(ctx, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -132,9 +173,11 @@ func (c *Client) PostWithStream(ctx context.Context, url string, headers map[str
 	}
 	defer resp.Body.Close()
 
+	capture.status(resp.StatusCode)
 	if resp.StatusCode != http.StatusOK {
-		bodyContent := readResponseBodyForError(resp.Body, 1024)
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, bodyContent)
+		body := readResponseBodyForError(resp.Body, 1024)
+		capture.errorBody(body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: body}
 	}
 
 	scanner := bufio.NewScanner(resp.Body)
@@ -146,6 +189,7 @@ func (c *Client) PostWithStream(ctx context.Context, url string, headers map[str
 
 		if strings.HasPrefix(line, "data:") {
 			data := strings.TrimPrefix(line, "data:")
+			capture.event(data)
 
 			var eventMap map[string]any
 			if err := json.Unmarshal([]byte(data), &eventMap); err != nil {
@@ -158,7 +202,7 @@ func (c *Client) PostWithStream(ctx context.Context, url string, headers map[str
 			} else if eventMap["type"] == "end" {
 				return nil
 			} else {
-				return fmt.Errorf("服务器错误: %s", data)
+				return &StreamEventError{Event: data}
 			}
 		}
 	}
@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+)
+
+// Exchange 一次上游调用的原始请求与响应
+type Exchange struct {
+	Method       string
+	URL          string
+	RequestBody  []byte
+	StatusCode   int
+	ResponseBody []byte
+	StreamEvents []string // 流式响应逐条data内容
+	Err          error
+	Start        time.Time
+	Latency      time.Duration
+}
+
+// ExchangeRecorder 上游调用记录器，通过context传入，调用结束（含失败）时回调
+type ExchangeRecorder interface {
+	RecordExchange(ctx context.Context, exchange *Exchange)
+}
+
+type exchangeRecorderKey struct{}
+
+// WithExchangeRecorder 在context中挂载记录器，之后经由该context的Post/PostWithStream调用都会被记录
+func WithExchangeRecorder(ctx context.Context, recorder ExchangeRecorder) context.Context {
+	return context.WithValue(ctx, exchangeRecorderKey{}, recorder)
+}
+
+// exchangeCapture 单次调用的记录过程，未挂载记录器时为nil，所有方法对nil安全
+type exchangeCapture struct {
+	recorder ExchangeRecorder
+	exchange *Exchange
+	body     bytes.Buffer
+	tee      io.Reader
+}
+
+func startExchange(ctx context.Context, method, url string, requestBody []byte) *exchangeCapture {
+	recorder, _ := ctx.Value(exchangeRecorderKey{}).(ExchangeRecorder)
+	if recorder == nil {
+		return nil
+	}
+	return &exchangeCapture{
+		recorder: recorder,
+		exchange: &Exchange{Method: method, URL: url, RequestBody: requestBody, Start: time.Now()},
+	}
+}
+
+func (c *exchangeCapture) status(code int) {
+	if c != nil {
+		c.exchange.StatusCode = code
+	}
+}
+
+// reader 返回边读边记录的响应体
+func (c *exchangeCapture) reader(body io.Reader) io.Reader {
+	if c == nil {
+		return body
+	}
+	c.tee = io.TeeReader(body, &c.body)
+	return c.tee
+}
+
+// errorBody 记录非200时已读取的响应内容
+func (c *exchangeCapture) errorBody(content string) {
+	if c != nil {
+		c.body.WriteString(content)
+	}
+}
+
+func (c *exchangeCapture) event(data string) {
+	if c != nil {
+		c.exchange.StreamEvents = append(c.exchange.StreamEvents, data)
+	}
+}
+
+// drain 读完解码器未读取的剩余响应体，须在关闭响应体之前调用
+func (c *exchangeCapture) drain() {
+	if c != nil && c.tee != nil {
+		_, _ = io.Copy(io.Discard, c.tee)
+	}
+}
+
+// finish 回调记录器
+func (c *exchangeCapture) finish(ctx context.Context, err error) {
+	if c == nil {
+		return
+	}
+	c.exchange.ResponseBody = c.body.Bytes()
+	c.exchange.Err = err
+	c.exchange.Latency = time.Since(c.exchange.Start)
+	c.recorder.RecordExchange(ctx, c.exchange)
+}
@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type lastExchange struct {
+	exchange *Exchange
+}
+
+func (r *lastExchange) RecordExchange(ctx context.Context, exchange *Exchange) {
+	r.exchange = exchange
+}
+
+// 记录的响应体包含JSON解码器未读取的剩余内容
+func TestPostRecordsFullResponseBody(t *testing.T) {
+	const body = `{"code":"0"}` + "\n" + `{"trailing":true}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"0"}` + "\n"))
+		w.(http.Flusher).Flush()
+		// 剩余内容晚于JSON值到达，解码器不会读到
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"trailing":true}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		post func(ctx context.Context, c *Client, result any) error
+	}{
+		{"Post", func(ctx context.Context, c *Client, result any) error {
+			return c.Post(ctx, server.URL, map[string]any{}, result)
+		}},
+		{"PostWithHeaders", func(ctx context.Context, c *Client, result any) error {
+			return c.PostWithHeaders(ctx, server.URL, map[string]any{}, result, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &lastExchange{}
+			var result map[string]any
+			if err := tt.post(WithExchangeRecorder(context.Background(), recorder), New(), &result); err != nil {
+				t.Fatal(err)
+			}
+			if recorder.exchange == nil {
+				t.Fatal("未记录调用")
+			}
+			if got := string(recorder.exchange.ResponseBody); got != body {
+				t.Errorf("ResponseBody = %q, want %q", got, body)
+			}
+		})
+	}
+}